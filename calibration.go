@@ -0,0 +1,209 @@
+package compare
+
+import (
+	"math"
+	"sort"
+)
+
+// Decision is a calibrated verdict of a text matching.
+type Decision int
+
+const (
+	// DecisionNone means the text doesn't match any stored text.
+	DecisionNone Decision = iota
+	// DecisionPartial means the text shares a notable part with a stored text.
+	DecisionPartial
+	// DecisionNearExact means the text is a stored text with minor changes.
+	DecisionNearExact
+	// DecisionExact means the text is a stored text.
+	DecisionExact
+)
+
+// String returns a human readable name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case DecisionExact:
+		return "exact"
+	case DecisionNearExact:
+		return "near-exact"
+	case DecisionPartial:
+		return "partial"
+	default:
+		return "none"
+	}
+}
+
+// Calibration contains thresholds of confidence for decisions.
+// A match with confidence equal to or above a threshold gets the decision.
+type Calibration struct {
+	Exact     float64
+	NearExact float64
+	Partial   float64
+	// Tolerance is the maximum difference of confidence between the best match
+	// and other ones to consider the result ambiguous.
+	Tolerance float64
+}
+
+// DefaultCalibration returns thresholds that fit license texts of a usual length.
+func DefaultCalibration() Calibration {
+	return Calibration{
+		Exact:     0.99,
+		NearExact: 0.9,
+		Partial:   0.5,
+		Tolerance: 0.02,
+	}
+}
+
+// Decide returns the decision for the confidence.
+func (c Calibration) Decide(confidence float64) Decision {
+	switch {
+	case confidence >= c.Exact:
+		return DecisionExact
+	case confidence >= c.NearExact:
+		return DecisionNearExact
+	case confidence >= c.Partial:
+		return DecisionPartial
+	default:
+		return DecisionNone
+	}
+}
+
+// Classification is a calibrated result of matching.
+type Classification struct {
+	Decision Decision
+	// Best is the match with the highest confidence.
+	Best Match
+	// Candidates are all the matches ordered by confidence descending.
+	Candidates []Match
+	// Margin is the difference of confidence between the two best candidates.
+	Margin float64
+	// Ambiguous is set when more than one candidate is within the tolerance
+	// from the best one, so the decision can't point a single text.
+	Ambiguous bool
+}
+
+// Sample is a text labeled with the expected decision, it is used to fit calibration.
+type Sample struct {
+	Text     string
+	Decision Decision
+}
+
+// SetCalibration replaces thresholds used by Classify.
+func (mm *TextMatcher) SetCalibration(calibration Calibration) {
+	mm.calibration = &calibration
+}
+
+// Calibration returns thresholds used by Classify.
+func (mm *TextMatcher) Calibration() Calibration {
+	if mm.calibration == nil {
+		return DefaultCalibration()
+	}
+
+	return *mm.calibration
+}
+
+// Classify matches the text with stored texts and makes a calibrated decision on the result.
+func (mm *TextMatcher) Classify(text string) Classification {
+	calibration := mm.Calibration()
+
+	candidates := mm.Match(text)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Confidence > candidates[j].Confidence
+	})
+
+	result := Classification{
+		Candidates: candidates,
+	}
+
+	if len(candidates) == 0 {
+		return result
+	}
+
+	result.Best = candidates[0]
+	result.Decision = calibration.Decide(result.Best.Confidence)
+	result.Margin = result.Best.Confidence
+
+	if len(candidates) > 1 {
+		result.Margin -= candidates[1].Confidence
+		result.Ambiguous = result.Decision != DecisionNone && result.Margin <= calibration.Tolerance
+	}
+
+	return result
+}
+
+// FitCalibration fits thresholds on the labeled samples and applies them to the matcher.
+// Each threshold is chosen to make the fewest wrong decisions on the boundary it separates,
+// thresholds without samples on both sides of the boundary stay unchanged.
+func (mm *TextMatcher) FitCalibration(samples []Sample) Calibration {
+	calibration := mm.Calibration()
+
+	scores := make([]float64, len(samples))
+	for i, sample := range samples {
+		scores[i] = mm.Classify(sample.Text).Best.Confidence
+	}
+
+	thresholds := []*float64{
+		DecisionPartial:   &calibration.Partial,
+		DecisionNearExact: &calibration.NearExact,
+		DecisionExact:     &calibration.Exact,
+	}
+
+	for decision := DecisionPartial; decision <= DecisionExact; decision++ {
+		if threshold, ok := fitThreshold(samples, scores, decision); ok {
+			*thresholds[decision] = threshold
+		}
+	}
+
+	calibration.NearExact = math.Min(calibration.NearExact, calibration.Exact)
+	calibration.Partial = math.Min(calibration.Partial, calibration.NearExact)
+
+	mm.SetCalibration(calibration)
+
+	return calibration
+}
+
+// fitThreshold finds the threshold which separates samples with decision
+// lower than the given one from the rest with the least errors.
+func fitThreshold(samples []Sample, scores []float64, decision Decision) (float64, bool) {
+	positives, negatives := 0, 0
+	for _, sample := range samples {
+		if sample.Decision >= decision {
+			positives++
+		} else {
+			negatives++
+		}
+	}
+
+	if positives == 0 || negatives == 0 {
+		return 0, false
+	}
+
+	sorted := append([]float64(nil), scores...)
+	sort.Float64s(sorted)
+
+	candidates := []float64{sorted[0]}
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] != sorted[i-1] {
+			candidates = append(candidates, (sorted[i]+sorted[i-1])/2)
+		}
+	}
+	candidates = append(candidates, math.Nextafter(sorted[len(sorted)-1], math.Inf(1)))
+
+	best, bestErrors := 0.0, len(samples)+1
+
+	for _, threshold := range candidates {
+		errors := 0
+
+		for i, sample := range samples {
+			if (scores[i] >= threshold) != (sample.Decision >= decision) {
+				errors++
+			}
+		}
+
+		if errors < bestErrors {
+			best, bestErrors = threshold, errors
+		}
+	}
+
+	return best, true
+}
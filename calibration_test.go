@@ -0,0 +1,96 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibration_Decide(t *testing.T) {
+	calibration := DefaultCalibration()
+
+	testcases := map[float64]Decision{
+		1:    DecisionExact,
+		0.95: DecisionNearExact,
+		0.7:  DecisionPartial,
+		0.2:  DecisionNone,
+		0:    DecisionNone,
+	}
+
+	for confidence, expected := range testcases {
+		assert.Equal(t, expected, calibration.Decide(confidence), confidence)
+	}
+}
+
+func TestMatcher_Classify(t *testing.T) {
+	t.Run("exact", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+
+		result := matcher.Classify(dummyTexts()[0].Content)
+
+		assert.Equal(t, DecisionExact, result.Decision)
+		assert.Equal(t, "lorem_ipsum", result.Best.TextName)
+		assert.Equal(t, 1., result.Margin)
+		assert.False(t, result.Ambiguous)
+		assert.Len(t, result.Candidates, 6)
+	})
+
+	t.Run("ambiguous", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+
+		result := matcher.Classify(dummyTexts()[1].Content)
+
+		assert.Equal(t, DecisionExact, result.Decision)
+		assert.Equal(t, "excepteur_sint", result.Best.TextName)
+		assert.Zero(t, result.Margin)
+		assert.True(t, result.Ambiguous)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+
+		result := matcher.Classify("Not matching text.")
+
+		assert.Equal(t, DecisionNone, result.Decision)
+		assert.False(t, result.Ambiguous)
+	})
+
+	t.Run("empty_matcher", func(t *testing.T) {
+		matcher := NewTextMatcher()
+
+		result := matcher.Classify("Lorem ipsum.")
+
+		assert.Equal(t, DecisionNone, result.Decision)
+		assert.Empty(t, result.Candidates)
+	})
+}
+
+func TestMatcher_FitCalibration(t *testing.T) {
+	matcher := NewTextMatcher(dummyTexts()[0])
+
+	samples := []Sample{
+		{Text: dummyTexts()[0].Content, Decision: DecisionExact},
+		{
+			Text: `Lorem ipsum dolor sit amet, consectetur adipiscing elit,
+			sed do eiusmod tempor incididunt ut labore et dolore magna aliqua.
+			Ut enim ad minim veniam, quis nostrud exercitation ullamco.`,
+			Decision: DecisionNearExact,
+		},
+		{
+			Text:     `Lorem ipsum dolor sit amet, consectetur adipiscing elit.`,
+			Decision: DecisionPartial,
+		},
+		{Text: `Excepteur sint occaecat cupidatat non proident.`, Decision: DecisionNone},
+	}
+
+	calibration := matcher.FitCalibration(samples)
+
+	assert.Equal(t, calibration, matcher.Calibration())
+	assert.GreaterOrEqual(t, calibration.Exact, calibration.NearExact)
+	assert.GreaterOrEqual(t, calibration.NearExact, calibration.Partial)
+	assert.Equal(t, DefaultCalibration().Tolerance, calibration.Tolerance)
+
+	for _, sample := range samples {
+		assert.Equal(t, sample.Decision, matcher.Classify(sample.Text).Decision, sample.Text)
+	}
+}
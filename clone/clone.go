@@ -0,0 +1,288 @@
+// Package clone detects copied source code between files.
+//
+// Source is split in tokens by a language aware lexer, so formatting and comments
+// don't matter, and identifiers and literals may be normalized to placeholders
+// to find clones with renamed variables. Both token sequences are cut in
+// overlapping windows, windows are compared by their markov chains and similar
+// windows are joined in fragments with line ranges in both files.
+package clone
+
+import (
+	"sort"
+
+	"github.com/radikh/compare/markov"
+)
+
+// Options configures the detection.
+type Options struct {
+	// Window is the number of tokens in a compared window, 50 when it's zero.
+	// It is the shortest clone the detection finds.
+	Window int
+	// Step is the offset between windows of the second file, a quarter
+	// of the window when it's zero. Windows of the first file are shifted by one token,
+	// so a smaller step finds clones more accurately but slower.
+	Step int
+	// Threshold is the minimal similarity of windows to be a clone, 0.8 when it's zero.
+	Threshold float64
+}
+
+func (o Options) withDefaults() Options {
+	if o.Window <= 0 {
+		o.Window = 50
+	}
+
+	if o.Step <= 0 {
+		o.Step = o.Window / 4
+	}
+
+	if o.Step <= 0 {
+		o.Step = 1
+	}
+
+	if o.Threshold <= 0 {
+		o.Threshold = 0.8
+	}
+
+	return o
+}
+
+// Region is a range of tokens of a file with lines they occupy.
+// Token indexes are half-open, lines are inclusive.
+type Region struct {
+	StartToken, EndToken int
+	StartLine, EndLine   int
+}
+
+// Fragment is a piece of code found in both files.
+type Fragment struct {
+	A, B Region
+	// Score is the similarity of the regions.
+	Score float64
+}
+
+// Source is a named tokenized file.
+type Source struct {
+	Name   string
+	Tokens []Token
+}
+
+// Clone is a pair of files which share fragments.
+type Clone struct {
+	A, B      string
+	Fragments []Fragment
+}
+
+// DetectAll finds fragments shared by each pair of the sources.
+// Pairs without shared fragments are not reported.
+func DetectAll(sources []Source, options Options) []Clone {
+	clones := []Clone{}
+
+	for i := range sources {
+		for j := i + 1; j < len(sources); j++ {
+			fragments := Detect(sources[i].Tokens, sources[j].Tokens, options)
+			if len(fragments) > 0 {
+				clones = append(clones, Clone{A: sources[i].Name, B: sources[j].Name, Fragments: fragments})
+			}
+		}
+	}
+
+	return clones
+}
+
+// window is a range of tokens with its frozen chain.
+type window struct {
+	start, end int
+	chain      *markov.FrozenChain[string]
+}
+
+// Detect finds fragments of the a tokens which are similar to fragments of the b tokens.
+// Fragments are ordered by their position in a.
+func Detect(a, b []Token, options Options) []Fragment {
+	options = options.withDefaults()
+
+	windowsA := windows(a, options.Window, 1)
+	windowsB := windows(b, options.Window, options.Step)
+
+	// postings index windows of b by their pairs, so only windows
+	// sharing a pair with a window of a are compared.
+	postings := map[markov.Pair[string]][]int{}
+	for i, w := range windowsB {
+		for _, pair := range w.chain.Pairs() {
+			postings[pair] = append(postings[pair], i)
+		}
+	}
+
+	var matches []fragmentSpan
+
+	for _, wa := range windowsA {
+		candidates := map[int]struct{}{}
+		for _, pair := range wa.chain.Pairs() {
+			for _, i := range postings[pair] {
+				candidates[i] = struct{}{}
+			}
+		}
+
+		for i := range candidates {
+			wb := windowsB[i]
+			if wa.chain.Compare(wb.chain) >= options.Threshold {
+				matches = append(matches, fragmentSpan{aStart: wa.start, aEnd: wa.end, bStart: wb.start, bEnd: wb.end})
+			}
+		}
+	}
+
+	fragments := []Fragment{}
+	for _, span := range joinSpans(matches) {
+		span = trimSpan(a, b, span)
+		fragments = append(fragments, Fragment{
+			A:     region(a, span.aStart, span.aEnd),
+			B:     region(b, span.bStart, span.bEnd),
+			Score: chainOf(a[span.aStart:span.aEnd]).Compare(chainOf(b[span.bStart:span.bEnd])),
+		})
+	}
+
+	return fragments
+}
+
+// windows cuts the tokens in windows of the size shifted by the step,
+// the last window is aligned to the end of tokens. Tokens shorter
+// than the window make no windows.
+func windows(tokens []Token, size, step int) []window {
+	if len(tokens) < size || size < 2 {
+		return nil
+	}
+
+	result := []window{}
+
+	for start := 0; ; start += step {
+		if start+size > len(tokens) {
+			start = len(tokens) - size
+		}
+
+		result = append(result, window{start: start, end: start + size, chain: chainOf(tokens[start : start+size])})
+
+		if start+size == len(tokens) {
+			return result
+		}
+	}
+}
+
+func chainOf(tokens []Token) *markov.FrozenChain[string] {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+
+	return markov.Freeze(markov.BuildChain(words))
+}
+
+// fragmentSpan is a pair of half-open tokens ranges of both files.
+type fragmentSpan struct {
+	aStart, aEnd int
+	bStart, bEnd int
+}
+
+// joinSpans joins matched windows which overlap in both files in continuous spans.
+func joinSpans(matches []fragmentSpan) []fragmentSpan {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].aStart != matches[j].aStart {
+			return matches[i].aStart < matches[j].aStart
+		}
+		return matches[i].bStart < matches[j].bStart
+	})
+
+	var spans []fragmentSpan
+
+	for _, match := range matches {
+		joined := false
+
+		for i := range spans {
+			span := &spans[i]
+			if match.aStart <= span.aEnd && match.bStart <= span.bEnd && match.bEnd >= span.bStart {
+				span.aEnd = max(span.aEnd, match.aEnd)
+				span.bStart = min(span.bStart, match.bStart)
+				span.bEnd = max(span.bEnd, match.bEnd)
+				joined = true
+
+				break
+			}
+		}
+
+		if !joined {
+			spans = append(spans, match)
+		}
+	}
+
+	return spans
+}
+
+// trimSpan localizes the span: tokens at its edges are dropped while
+// the pair they start or end is absent in the span of the other file,
+// as windows joined in a span often cover a bit of unrelated code.
+func trimSpan(a, b []Token, span fragmentSpan) fragmentSpan {
+	for {
+		pairsA := pairSet(a[span.aStart:span.aEnd])
+		pairsB := pairSet(b[span.bStart:span.bEnd])
+
+		aStart, aEnd := trimEdges(a, span.aStart, span.aEnd, pairsB)
+		bStart, bEnd := trimEdges(b, span.bStart, span.bEnd, pairsA)
+
+		trimmed := fragmentSpan{aStart: aStart, aEnd: aEnd, bStart: bStart, bEnd: bEnd}
+		if trimmed == span {
+			return span
+		}
+
+		span = trimmed
+	}
+}
+
+// trimEdges shrinks the range while its edge pairs are absent in the pairs set,
+// at least two tokens are always kept.
+func trimEdges(tokens []Token, start, end int, pairs map[markov.Pair[string]]struct{}) (int, int) {
+	for end-start > 2 {
+		if _, ok := pairs[markov.Pair[string]{First: tokens[start].Text, Second: tokens[start+1].Text}]; ok {
+			break
+		}
+		start++
+	}
+
+	for end-start > 2 {
+		if _, ok := pairs[markov.Pair[string]{First: tokens[end-2].Text, Second: tokens[end-1].Text}]; ok {
+			break
+		}
+		end--
+	}
+
+	return start, end
+}
+
+func pairSet(tokens []Token) map[markov.Pair[string]]struct{} {
+	pairs := make(map[markov.Pair[string]]struct{}, len(tokens))
+	for i := 1; i < len(tokens); i++ {
+		pairs[markov.Pair[string]{First: tokens[i-1].Text, Second: tokens[i].Text}] = struct{}{}
+	}
+
+	return pairs
+}
+
+func region(tokens []Token, start, end int) Region {
+	return Region{
+		StartToken: start,
+		EndToken:   end,
+		StartLine:  tokens[start].Line,
+		EndLine:    tokens[end-1].Line,
+	}
+}
+
+func max(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,118 @@
+package clone
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const original = `package stats
+
+// Mean returns the arithmetic mean of values.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+
+	return total / float64(len(values))
+}
+`
+
+// copied contains Mean with renamed identifiers surrounded by unrelated code.
+const copied = `package metrics
+
+import "strings"
+
+func Join(parts []string) string {
+	return strings.Join(parts, ", ")
+}
+
+func Average(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+
+	return sum / float64(len(xs))
+}
+
+func Upper(s string) string { return strings.ToUpper(s) }
+`
+
+func tokenize(t *testing.T, src string, normalization Normalization) []Token {
+	tokens, err := TokenizeGo([]byte(src), normalization)
+	require.NoError(t, err)
+
+	return tokens
+}
+
+func TestDetect(t *testing.T) {
+	options := Options{Window: 20, Step: 2, Threshold: 0.8}
+
+	t.Run("type_2", func(t *testing.T) {
+		normalization := Normalization{NormalizeIdentifiers: true, NormalizeLiterals: true}
+
+		fragments := Detect(tokenize(t, original, normalization), tokenize(t, copied, normalization), options)
+		require.Len(t, fragments, 1)
+
+		fragment := fragments[0]
+		assert.Equal(t, 4, fragment.A.StartLine)
+		assert.Equal(t, 15, fragment.A.EndLine)
+		assert.LessOrEqual(t, fragment.B.StartLine, 9)
+		assert.GreaterOrEqual(t, fragment.B.EndLine, 20)
+		assert.Greater(t, fragment.Score, 0.8)
+	})
+
+	t.Run("renamed_without_normalization", func(t *testing.T) {
+		fragments := Detect(tokenize(t, original, Normalization{}), tokenize(t, copied, Normalization{}), options)
+		for _, fragment := range fragments {
+			assert.Less(t, fragment.A.EndToken-fragment.A.StartToken, 40)
+		}
+
+		strict := options
+		strict.Threshold = 0.9
+		assert.Empty(t, Detect(tokenize(t, original, Normalization{}), tokenize(t, copied, Normalization{}), strict))
+	})
+
+	t.Run("exact_copy", func(t *testing.T) {
+		tokens := tokenize(t, original, Normalization{})
+
+		fragments := Detect(tokens, tokens, options)
+		require.Len(t, fragments, 1)
+		assert.Equal(t, Region{StartToken: 0, EndToken: len(tokens), StartLine: 1, EndLine: 15}, fragments[0].A)
+		assert.Equal(t, fragments[0].A, fragments[0].B)
+		assert.Equal(t, 1.0, fragments[0].Score)
+	})
+
+	t.Run("short_sources", func(t *testing.T) {
+		tokens := tokenize(t, "package a", Normalization{})
+		assert.Empty(t, Detect(tokens, tokens, Options{}))
+	})
+}
+
+func TestDetectAll(t *testing.T) {
+	normalization := Normalization{NormalizeIdentifiers: true, NormalizeLiterals: true}
+
+	sources := []Source{
+		{Name: "stats.go", Tokens: tokenize(t, original, normalization)},
+		{Name: "other.go", Tokens: tokenize(t, "package other\n\nfunc F() {"+strings.Repeat(" println(1);", 10)+" }\n", normalization)},
+		{Name: "metrics.go", Tokens: tokenize(t, copied, normalization)},
+	}
+
+	clones := DetectAll(sources, Options{Window: 20, Step: 2})
+	require.Len(t, clones, 1)
+	assert.Equal(t, "stats.go", clones[0].A)
+	assert.Equal(t, "metrics.go", clones[0].B)
+	assert.Len(t, clones[0].Fragments, 1)
+}
@@ -0,0 +1,243 @@
+package clone
+
+import (
+	"bytes"
+	"go/scanner"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// IdentifierPlaceholder replaces identifiers with NormalizeIdentifiers.
+	IdentifierPlaceholder = "$id"
+	// LiteralPlaceholder replaces literals with NormalizeLiterals.
+	LiteralPlaceholder = "$lit"
+)
+
+// Token is a token of source code, comments are not tokens.
+type Token struct {
+	Text string
+	// Line is the line number of the token start, starting from 1.
+	Line int
+}
+
+// Normalization defines which tokens are replaced with placeholders.
+// Normalized identifiers and literals find clones with renamed variables
+// and changed constants, known as type-2 clones.
+type Normalization struct {
+	NormalizeIdentifiers bool
+	NormalizeLiterals    bool
+}
+
+// kind is a class of a token which normalization cares about.
+type kind int
+
+const (
+	kindOther kind = iota
+	kindIdentifier
+	kindLiteral
+)
+
+func (n Normalization) apply(text string, k kind) string {
+	switch {
+	case k == kindIdentifier && n.NormalizeIdentifiers:
+		return IdentifierPlaceholder
+	case k == kindLiteral && n.NormalizeLiterals:
+		return LiteralPlaceholder
+	default:
+		return text
+	}
+}
+
+// Tokenize splits the source in tokens with the lexer chosen by the file name:
+// Go files are scanned with go/scanner and other files with the C-like lexer.
+func Tokenize(name string, src []byte, normalization Normalization) ([]Token, error) {
+	if strings.EqualFold(filepath.Ext(name), ".go") {
+		return TokenizeGo(src, normalization)
+	}
+
+	return TokenizeCLike(src, normalization), nil
+}
+
+// TokenizeGo splits Go source in tokens, comments and automatically inserted semicolons are skipped.
+// Keywords are never normalized.
+func TokenizeGo(src []byte, normalization Normalization) ([]Token, error) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var errs scanner.ErrorList
+
+	var s scanner.Scanner
+	s.Init(file, src, func(pos token.Position, msg string) {
+		errs.Add(pos, msg)
+	}, 0)
+
+	tokens := []Token{}
+
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+
+		k, text := kindOther, tok.String()
+
+		switch {
+		case tok == token.IDENT:
+			k, text = kindIdentifier, lit
+		case tok.IsLiteral():
+			k, text = kindLiteral, lit
+		}
+
+		tokens = append(tokens, Token{
+			Text: normalization.apply(text, k),
+			Line: file.Line(pos),
+		})
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// TokenizeCLike splits source of a language with C-like syntax in tokens:
+// C, C++, Java, JavaScript, C# and others. It knows line and block comments,
+// quoted strings and characters, numbers, identifiers and operators.
+// Keywords of such languages are never normalized. The lexer never fails,
+// unknown characters become single character tokens.
+func TokenizeCLike(src []byte, normalization Normalization) []Token {
+	tokens := []Token{}
+	line := 1
+
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRune(src[i:])
+
+		switch {
+		case r == '\n':
+			line++
+			i++
+		case unicode.IsSpace(r):
+			i += size
+		case r == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(src) && src[i+1] == '*':
+			end := strings.Index(string(src[i+2:]), "*/")
+			if end < 0 {
+				end = len(src) - i - 2
+			} else {
+				end += 2
+			}
+
+			line += strings.Count(string(src[i:i+2+end]), "\n")
+			i += 2 + end
+		case r == '"' || r == '\'' || r == '`':
+			end := quotedEnd(src, i)
+			tokens = append(tokens, Token{Text: normalization.apply(string(src[i:end]), kindLiteral), Line: line})
+			line += strings.Count(string(src[i:end]), "\n")
+			i = end
+		case unicode.IsDigit(r):
+			end := i + size
+			for end < len(src) {
+				next, nextSize := utf8.DecodeRune(src[end:])
+				if next != '_' && next != '.' && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+					break
+				}
+				end += nextSize
+			}
+
+			tokens = append(tokens, Token{Text: normalization.apply(string(src[i:end]), kindLiteral), Line: line})
+			i = end
+		case r == '_' || r == '$' || unicode.IsLetter(r):
+			end := i + size
+			for end < len(src) {
+				next, nextSize := utf8.DecodeRune(src[end:])
+				if next != '_' && next != '$' && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+					break
+				}
+				end += nextSize
+			}
+
+			word, k := string(src[i:end]), kindIdentifier
+			if isCLikeKeyword(word) {
+				k = kindOther
+			}
+
+			tokens = append(tokens, Token{Text: normalization.apply(word, k), Line: line})
+			i = end
+		default:
+			operator := operatorAt(src[i:])
+			if operator == "" {
+				operator = string(src[i : i+size])
+			}
+
+			tokens = append(tokens, Token{Text: operator, Line: line})
+			i += len(operator)
+		}
+	}
+
+	return tokens
+}
+
+// quotedEnd returns the index after the closing quote of the literal started at i,
+// escaped quotes are skipped. Unterminated literals end at the end of the line,
+// except for backtick ones, which may span lines.
+func quotedEnd(src []byte, i int) int {
+	quote := src[i]
+
+	for j := i + 1; j < len(src); j++ {
+		switch {
+		case src[j] == '\\' && quote != '`':
+			j++
+		case src[j] == quote:
+			return j + 1
+		case src[j] == '\n' && quote != '`':
+			return j
+		}
+	}
+
+	return len(src)
+}
+
+// operatorAt returns the longest multi-character operator the source starts with.
+func operatorAt(src []byte) string {
+	for _, operator := range []string{
+		">>>=", "<<=", ">>=", ">>>", "...", "===", "!==", "->*", "<=>",
+		"&&", "||", "==", "!=", "<=", ">=", "++", "--", "+=", "-=", "*=", "/=", "%=",
+		"&=", "|=", "^=", "<<", ">>", "->", "::", "=>", "??", "?.", "**",
+	} {
+		if bytes.HasPrefix(src, []byte(operator)) {
+			return operator
+		}
+	}
+
+	return ""
+}
+
+// isCLikeKeyword reports whether the word is a keyword of a common C-like language.
+func isCLikeKeyword(word string) bool {
+	switch word {
+	case "abstract", "auto", "bool", "boolean", "break", "byte", "case", "catch", "char",
+		"class", "const", "continue", "default", "delete", "do", "double", "else", "enum",
+		"export", "extends", "extern", "false", "final", "finally", "float", "for", "function",
+		"goto", "if", "implements", "import", "in", "instanceof", "int", "interface", "let",
+		"long", "namespace", "new", "null", "nullptr", "package", "private", "protected",
+		"public", "register", "return", "short", "signed", "sizeof", "static", "struct",
+		"super", "switch", "template", "this", "throw", "throws", "true", "try", "typedef",
+		"typeof", "union", "unsigned", "using", "var", "virtual", "void", "volatile", "while",
+		"yield", "async", "await", "string":
+		return true
+	default:
+		return false
+	}
+}
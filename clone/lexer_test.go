@@ -0,0 +1,89 @@
+package clone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func texts(tokens []Token) []string {
+	result := make([]string, len(tokens))
+	for i, token := range tokens {
+		result[i] = token.Text
+	}
+
+	return result
+}
+
+func TestTokenizeGo(t *testing.T) {
+	src := []byte("package main\n\n// Sum adds numbers.\nfunc sum(a, b int) int {\n\treturn a + b * 2\n}\n")
+
+	t.Run("plain", func(t *testing.T) {
+		tokens, err := TokenizeGo(src, Normalization{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"package", "main",
+			"func", "sum", "(", "a", ",", "b", "int", ")", "int", "{",
+			"return", "a", "+", "b", "*", "2",
+			"}",
+		}, texts(tokens))
+
+		assert.Equal(t, 1, tokens[0].Line)
+		assert.Equal(t, 4, tokens[2].Line)
+		assert.Equal(t, 5, tokens[12].Line)
+		assert.Equal(t, 6, tokens[18].Line)
+	})
+
+	t.Run("normalized", func(t *testing.T) {
+		tokens, err := TokenizeGo(src, Normalization{NormalizeIdentifiers: true, NormalizeLiterals: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"return", "$id", "+", "$id", "*", "$lit"}, texts(tokens[12:18]))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := TokenizeGo([]byte("package main\nvar s = \"unterminated\n"), Normalization{})
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenizeCLike(t *testing.T) {
+	src := []byte("/* header\n * comment */\nint count(const char *s) {\n" +
+		"  int n = 0; // counter\n  while (*s++ != '\\0') n += 1;\n  return n >= 0x10 ? \"big\" : \"small\";\n}\n")
+
+	tokens := TokenizeCLike(src, Normalization{})
+	assert.Equal(t, []string{
+		"int", "count", "(", "const", "char", "*", "s", ")", "{",
+		"int", "n", "=", "0", ";",
+		"while", "(", "*", "s", "++", "!=", "'\\0'", ")", "n", "+=", "1", ";",
+		"return", "n", ">=", "0x10", "?", `"big"`, ":", `"small"`, ";",
+		"}",
+	}, texts(tokens))
+
+	assert.Equal(t, 3, tokens[0].Line)
+	assert.Equal(t, 4, tokens[9].Line)
+	assert.Equal(t, 7, tokens[len(tokens)-1].Line)
+
+	normalized := TokenizeCLike(src, Normalization{NormalizeIdentifiers: true, NormalizeLiterals: true})
+	assert.Equal(t, []string{"int", "$id", "(", "const", "char", "*", "$id", ")", "{"}, texts(normalized[:9]))
+	assert.Equal(t, "$lit", normalized[20].Text)
+
+	t.Run("non_ascii_digits", func(t *testing.T) {
+		tokens := TokenizeCLike([]byte("x = ١٢٣; y = １２.5f;"), Normalization{})
+
+		assert.Equal(t, []string{"x", "=", "١٢٣", ";", "y", "=", "１２.5f", ";"}, texts(tokens))
+	})
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := Tokenize("main.GO", []byte("package main"), Normalization{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"package", "main"}, texts(tokens))
+
+	tokens, err = Tokenize("main.js", []byte("let x = `a\nb`; x"), Normalization{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"let", "x", "=", "`a\nb`", ";", "x"}, texts(tokens))
+	assert.Equal(t, 2, tokens[5].Line)
+}
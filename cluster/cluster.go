@@ -0,0 +1,207 @@
+package cluster
+
+import (
+	"math"
+	"sort"
+)
+
+// Cluster is a group of near-duplicate documents.
+type Cluster struct {
+	// Members are indexes of the documents in ascending order.
+	Members []int
+	// Representative is the medoid of the cluster: the member
+	// with the highest total similarity to other members.
+	Representative int
+	// MeanScore is the average similarity of the members pairs.
+	MeanScore float64
+	// MinScore is the lowest similarity of the members pairs.
+	MinScore float64
+}
+
+// Linkage defines the similarity of two clusters in agglomerative clustering.
+type Linkage int
+
+const (
+	// SingleLinkage is the similarity of the closest members of the clusters.
+	SingleLinkage Linkage = iota
+	// CompleteLinkage is the similarity of the farthest members of the clusters.
+	CompleteLinkage
+	// AverageLinkage is the average similarity of the members pairs of the clusters.
+	AverageLinkage
+)
+
+// Components returns connected components of the graph, so documents are in
+// the same cluster when there is a chain of edges between them.
+// It is the single-link clustering cut at the graph threshold.
+// Clusters are ordered by size descending, documents without edges are singletons.
+func (g *Graph) Components() []Cluster {
+	component := make([]int, g.Len())
+	for i := range component {
+		component[i] = -1
+	}
+
+	groups := [][]int{}
+
+	for start := range component {
+		if component[start] >= 0 {
+			continue
+		}
+
+		id := len(groups)
+		component[start] = id
+		members := []int{start}
+
+		for queue := []int{start}; len(queue) > 0; queue = queue[1:] {
+			for neighbour := range g.adjacency[queue[0]] {
+				if component[neighbour] < 0 {
+					component[neighbour] = id
+					members = append(members, neighbour)
+					queue = append(queue, neighbour)
+				}
+			}
+		}
+
+		groups = append(groups, members)
+	}
+
+	return g.clusters(groups)
+}
+
+// Agglomerative merges the two most similar clusters starting from singletons
+// until the similarity of the most similar clusters is below the threshold.
+// Documents which are not connected in the graph have zero similarity,
+// so a threshold below the graph one acts as the graph threshold.
+// Clusters are ordered by size descending.
+func (g *Graph) Agglomerative(linkage Linkage, threshold float64) []Cluster {
+	n := g.Len()
+
+	similarity := make([][]float64, n)
+	for i := range similarity {
+		similarity[i] = make([]float64, n)
+		for j, score := range g.adjacency[i] {
+			similarity[i][j] = score
+		}
+	}
+
+	groups := make([][]int, n)
+	for i := range groups {
+		groups[i] = []int{i}
+	}
+
+	for {
+		a, b, best := -1, -1, 0.0
+
+		for i := range groups {
+			if groups[i] == nil {
+				continue
+			}
+
+			for j := i + 1; j < n; j++ {
+				if groups[j] != nil && similarity[i][j] > best {
+					a, b, best = i, j, similarity[i][j]
+				}
+			}
+		}
+
+		if a < 0 || best < threshold {
+			break
+		}
+
+		// The merged cluster takes the place of a, its similarities to others
+		// are updated by the Lance-Williams formula of the linkage.
+		for k := range groups {
+			if groups[k] == nil || k == a || k == b {
+				continue
+			}
+
+			var merged float64
+
+			switch linkage {
+			case CompleteLinkage:
+				merged = math.Min(similarity[a][k], similarity[b][k])
+			case AverageLinkage:
+				sizeA, sizeB := float64(len(groups[a])), float64(len(groups[b]))
+				merged = (sizeA*similarity[a][k] + sizeB*similarity[b][k]) / (sizeA + sizeB)
+			default:
+				merged = math.Max(similarity[a][k], similarity[b][k])
+			}
+
+			similarity[a][k], similarity[k][a] = merged, merged
+		}
+
+		groups[a] = append(groups[a], groups[b]...)
+		groups[b] = nil
+	}
+
+	result := [][]int{}
+	for _, group := range groups {
+		if group != nil {
+			result = append(result, group)
+		}
+	}
+
+	return g.clusters(result)
+}
+
+// clusters describes the groups of documents and orders them
+// by size descending and then by the smallest member.
+func (g *Graph) clusters(groups [][]int) []Cluster {
+	result := make([]Cluster, 0, len(groups))
+
+	for _, members := range groups {
+		sort.Ints(members)
+		result = append(result, g.describe(members))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Members) != len(result[j].Members) {
+			return len(result[i].Members) > len(result[j].Members)
+		}
+		return result[i].Members[0] < result[j].Members[0]
+	})
+
+	return result
+}
+
+func (g *Graph) describe(members []int) Cluster {
+	cluster := Cluster{
+		Members:        members,
+		Representative: members[0],
+		MeanScore:      1,
+		MinScore:       1,
+	}
+
+	if len(members) == 1 {
+		return cluster
+	}
+
+	total, pairs := 0.0, 0
+	bestSum := -1.0
+
+	for _, i := range members {
+		sum := 0.0
+
+		for _, j := range members {
+			if i == j {
+				continue
+			}
+
+			score := g.Similarity(i, j)
+			sum += score
+
+			if i < j {
+				total += score
+				pairs++
+				cluster.MinScore = math.Min(cluster.MinScore, score)
+			}
+		}
+
+		if sum > bestSum {
+			cluster.Representative, bestSum = i, sum
+		}
+	}
+
+	cluster.MeanScore = total / float64(pairs)
+
+	return cluster
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLicense(t *testing.T, name string) string {
+	content, err := os.ReadFile("../testdata/licenses/" + name + ".txt")
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func documents(t *testing.T) []compare.Text {
+	mit, isc := readLicense(t, "MIT"), readLicense(t, "ISC")
+
+	return []compare.Text{
+		{Name: "mit", Content: mit},
+		{Name: "isc", Content: isc},
+		{Name: "mit-copy", Content: strings.Replace(mit, "Permission", "Hereby permission", 1)},
+		{Name: "note", Content: "Call me when the build is green"},
+		{Name: "isc-copy", Content: strings.Replace(isc, "Permission to use", "Permission to use and share", 1)},
+		{Name: "mit-short", Content: mit[:len(mit)/2]},
+		{Name: "empty", Content: ""},
+	}
+}
+
+func TestNewGraph(t *testing.T) {
+	texts := documents(t)
+	g := NewGraph(texts, 0)
+
+	require.Equal(t, len(texts), g.Len())
+	assert.Equal(t, "mit-copy", g.Name(2))
+	assert.Equal(t, 1.0, g.Similarity(3, 3))
+
+	for i := range texts {
+		for j := range texts {
+			if i != j {
+				assert.Equal(t, compare.CompareTexts(texts[i].Content, texts[j].Content), g.Similarity(i, j),
+					"%s and %s", texts[i].Name, texts[j].Name)
+			}
+		}
+	}
+
+	pruned := NewGraph(texts, 0.9)
+	assert.Equal(t, 0.9, pruned.Threshold())
+
+	for _, edge := range pruned.Edges() {
+		assert.GreaterOrEqual(t, edge.Score, 0.9)
+		assert.Less(t, edge.A, edge.B)
+	}
+
+	assert.Equal(t, []Edge{
+		{A: 0, B: 2, Score: g.Similarity(0, 2)},
+		{A: 1, B: 4, Score: g.Similarity(1, 4)},
+	}, pruned.Edges())
+
+	t.Run("first_word_only", func(t *testing.T) {
+		for _, contents := range [][]string{
+			{"hello", "hello world"},
+			{"hello world", "hello"},
+			{"alpha beta", "alpha gamma"},
+		} {
+			texts := []compare.Text{{Name: "a", Content: contents[0]}, {Name: "b", Content: contents[1]}}
+			score := compare.CompareTexts(contents[0], contents[1])
+			require.Equal(t, 0.5, score)
+
+			assert.Equal(t, []Edge{{A: 0, B: 1, Score: score}}, NewGraph(texts, 0.5).Edges(), contents)
+		}
+	})
+}
+
+func TestGraph_Components(t *testing.T) {
+	g := NewGraph(documents(t), 0.45)
+
+	clusters := g.Components()
+	require.Len(t, clusters, 4)
+
+	assert.Equal(t, []int{0, 2, 5}, clusters[0].Members)
+	assert.Equal(t, 0, clusters[0].Representative)
+	assert.Equal(t, (g.Similarity(0, 2)+g.Similarity(0, 5)+g.Similarity(2, 5))/3, clusters[0].MeanScore)
+	assert.Equal(t, g.Similarity(2, 5), clusters[0].MinScore)
+
+	assert.Equal(t, []int{1, 4}, clusters[1].Members)
+	assert.Equal(t, Cluster{Members: []int{3}, Representative: 3, MeanScore: 1, MinScore: 1}, clusters[2])
+	assert.Equal(t, []int{6}, clusters[3].Members)
+}
+
+func TestGraph_Agglomerative(t *testing.T) {
+	g := NewGraph(documents(t), 0.3)
+
+	members := func(clusters []Cluster) [][]int {
+		result := [][]int{}
+		for _, cluster := range clusters {
+			result = append(result, cluster.Members)
+		}
+
+		return result
+	}
+
+	tests := map[string]struct {
+		linkage   Linkage
+		threshold float64
+		expected  [][]int
+	}{
+		"single": {
+			linkage:   SingleLinkage,
+			threshold: 0.45,
+			expected:  [][]int{{0, 2, 5}, {1, 4}, {3}, {6}},
+		},
+		"complete_strict": {
+			linkage:   CompleteLinkage,
+			threshold: 0.9,
+			expected:  [][]int{{0, 2}, {1, 4}, {3}, {5}, {6}},
+		},
+		"average": {
+			linkage:   AverageLinkage,
+			threshold: 0.45,
+			expected:  [][]int{{0, 2, 5}, {1, 4}, {3}, {6}},
+		},
+		"everything_apart": {
+			linkage:   SingleLinkage,
+			threshold: 1.1,
+			expected:  [][]int{{0}, {1}, {2}, {3}, {4}, {5}, {6}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, members(g.Agglomerative(tt.linkage, tt.threshold)))
+		})
+	}
+
+	t.Run("single_equals_components", func(t *testing.T) {
+		assert.Equal(t, g.Components(), g.Agglomerative(SingleLinkage, g.Threshold()))
+	})
+}
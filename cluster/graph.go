@@ -0,0 +1,157 @@
+// Package cluster groups near-duplicate documents, like vendored license copies,
+// by the similarity of their markov chains.
+//
+// A Graph keeps similarities of the documents pairs above a threshold.
+// Only documents that share a pair of words or the first word and have close enough lengths
+// are compared, so large collections of unrelated documents are cheap to process.
+// The graph is clustered either by connected components or by hierarchical
+// agglomerative clustering.
+package cluster
+
+import (
+	"sort"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/markov"
+)
+
+// Edge is the similarity of two documents, A is less than B.
+type Edge struct {
+	A, B  int
+	Score float64
+}
+
+// Graph is a similarity graph of documents.
+// Documents are identified by their indexes in the texts the graph is built from.
+type Graph struct {
+	names     []string
+	threshold float64
+	adjacency []map[int]float64
+}
+
+// NewGraph builds the similarity graph of the texts with Tokenize.
+// Similarities are the scores of CompareTexts, they are symmetric for chains,
+// and only the ones equal to or above the threshold become edges.
+func NewGraph(texts []compare.Text, threshold float64) *Graph {
+	g := &Graph{
+		names:     make([]string, len(texts)),
+		threshold: threshold,
+		adjacency: make([]map[int]float64, len(texts)),
+	}
+
+	chains := make([]*markov.FrozenChain[string], len(texts))
+	lengths := make([]int, len(texts))
+	firstWords := make([]string, len(texts))
+	postings := map[markov.Pair[string]][]int{}
+	// Compare counts a match of first words, so documents sharing only it may be similar too.
+	firstPostings := map[string][]int{}
+
+	for i, text := range texts {
+		words := compare.Tokenize(text.Content)
+
+		g.names[i] = text.Name
+		g.adjacency[i] = map[int]float64{}
+		chains[i] = markov.Freeze(markov.BuildChain(words))
+		lengths[i] = len(words)
+
+		if len(words) > 0 {
+			firstWords[i] = words[0]
+		}
+	}
+
+	for i := range texts {
+		candidates := map[int]struct{}{}
+
+		for _, pair := range chains[i].Pairs() {
+			for _, j := range postings[pair] {
+				candidates[j] = struct{}{}
+			}
+
+			postings[pair] = append(postings[pair], i)
+		}
+
+		if lengths[i] > 0 {
+			for _, j := range firstPostings[firstWords[i]] {
+				candidates[j] = struct{}{}
+			}
+
+			firstPostings[firstWords[i]] = append(firstPostings[firstWords[i]], i)
+		}
+
+		for j := range candidates {
+			// The matches count never exceeds the words count of the shorter document.
+			if float64(min(lengths[i], lengths[j])) < threshold*float64(max(lengths[i], lengths[j])) {
+				continue
+			}
+
+			score := chains[i].Compare(chains[j])
+			if score > 0 && score >= threshold {
+				g.adjacency[i][j] = score
+				g.adjacency[j][i] = score
+			}
+		}
+	}
+
+	return g
+}
+
+// Len returns the number of documents.
+func (g *Graph) Len() int {
+	return len(g.names)
+}
+
+// Name returns the name of the document.
+func (g *Graph) Name(i int) string {
+	return g.names[i]
+}
+
+// Threshold returns the minimal similarity of edges.
+func (g *Graph) Threshold() float64 {
+	return g.threshold
+}
+
+// Similarity returns the similarity of two documents, it is zero for documents
+// which are not connected and one for the document with itself.
+func (g *Graph) Similarity(i, j int) float64 {
+	if i == j {
+		return 1
+	}
+
+	return g.adjacency[i][j]
+}
+
+// Edges returns all the edges ordered by documents indexes.
+func (g *Graph) Edges() []Edge {
+	edges := []Edge{}
+
+	for i, neighbours := range g.adjacency {
+		for j, score := range neighbours {
+			if i < j {
+				edges = append(edges, Edge{A: i, B: j, Score: score})
+			}
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].A != edges[j].A {
+			return edges[i].A < edges[j].A
+		}
+		return edges[i].B < edges[j].B
+	})
+
+	return edges
+}
+
+func max(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func min(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
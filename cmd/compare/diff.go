@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxDiffCells limits the memory of the longest common subsequence table.
+const maxDiffCells = 1 << 24
+
+// writeDiff prints the tokens of both texts marking removed runs as [-...-]
+// and added runs as {+...+}, like wdiff does.
+func writeDiff(w io.Writer, tokens1, tokens2 []string) error {
+	operations, err := diffTokens(tokens1, tokens2)
+	if err != nil {
+		return err
+	}
+
+	parts := make([]string, 0, len(operations))
+
+	for i := 0; i < len(operations); {
+		j := i
+		for j < len(operations) && operations[j].kind == operations[i].kind {
+			j++
+		}
+
+		run := make([]string, 0, j-i)
+		for _, operation := range operations[i:j] {
+			run = append(run, operation.token)
+		}
+
+		switch text := strings.Join(run, " "); operations[i].kind {
+		case diffRemoved:
+			parts = append(parts, "[-"+text+"-]")
+		case diffAdded:
+			parts = append(parts, "{+"+text+"+}")
+		default:
+			parts = append(parts, text)
+		}
+
+		i = j
+	}
+
+	_, err = fmt.Fprintln(w, strings.Join(parts, " "))
+
+	return err
+}
+
+type diffKind int
+
+const (
+	diffEqual diffKind = iota
+	diffRemoved
+	diffAdded
+)
+
+type diffOperation struct {
+	kind  diffKind
+	token string
+}
+
+// diffTokens finds the longest common subsequence of the tokens,
+// the common prefix and suffix are skipped to keep the table small.
+func diffTokens(tokens1, tokens2 []string) ([]diffOperation, error) {
+	prefix := 0
+	for prefix < len(tokens1) && prefix < len(tokens2) && tokens1[prefix] == tokens2[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(tokens1)-prefix && suffix < len(tokens2)-prefix &&
+		tokens1[len(tokens1)-1-suffix] == tokens2[len(tokens2)-1-suffix] {
+		suffix++
+	}
+
+	a, b := tokens1[prefix:len(tokens1)-suffix], tokens2[prefix:len(tokens2)-suffix]
+	if (len(a)+1)*(len(b)+1) > maxDiffCells {
+		return nil, errors.New("texts are too different to diff")
+	}
+
+	// lengths[i][j] is the length of the common subsequence of a[i:] and b[j:].
+	lengths := make([][]int32, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	operations := make([]diffOperation, 0, len(tokens1)+len(tokens2))
+	for _, token := range tokens1[:prefix] {
+		operations = append(operations, diffOperation{kind: diffEqual, token: token})
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			operations = append(operations, diffOperation{kind: diffEqual, token: a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lengths[i+1][j] >= lengths[i][j+1]:
+			operations = append(operations, diffOperation{kind: diffRemoved, token: a[i]})
+			i++
+		default:
+			operations = append(operations, diffOperation{kind: diffAdded, token: b[j]})
+			j++
+		}
+	}
+
+	for _, token := range tokens1[len(tokens1)-suffix:] {
+		operations = append(operations, diffOperation{kind: diffEqual, token: token})
+	}
+
+	return operations, nil
+}
@@ -0,0 +1,243 @@
+// Command compare prints the similarity rate of two texts.
+//
+// Usage:
+//
+//	compare [flags] <file1> <file2>
+//
+// Either file, but not both, may be "-" to read it from the standard input.
+// The -profile flag selects how texts are split in tokens, -metric selects
+// the similarity measure and -n the length of compared n-grams, 2 compares pairs of words.
+// With -explain the n-grams specific for each text are listed
+// and with -diff the word level difference of the texts is printed.
+//
+// The exit code is 0 when the score reaches -threshold, 1 when it is below
+// and 2 on errors, so the command fits shell scripts and git hooks.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/markov"
+)
+
+const (
+	exitBelowThreshold = 1
+	exitError          = 2
+)
+
+// errBelowThreshold is returned when the score is below the threshold.
+var errBelowThreshold = errors.New("score is below the threshold")
+
+// tokenizer returns the function splitting texts in tokens by the profile name.
+func tokenizer(profile string) (func(string) []string, error) {
+	switch profile {
+	case "default":
+		return compare.Tokenize, nil
+	case "license":
+		return func(text string) []string {
+			return compare.LicenseTokenizer().Tokenize(compare.StripCopyrights(text))
+		}, nil
+	case "folded":
+		return compare.Tokenizer{FoldDiacritics: true, FoldConfusables: true}.Tokenize, nil
+	case "multilingual":
+		return compare.Tokenizer{Segmentation: compare.SegmentWords}.Tokenize, nil
+	case "words":
+		return strings.Fields, nil
+	default:
+		return nil, fmt.Errorf("unknown profile %q", profile)
+	}
+}
+
+// similarity returns the similarity measure of token sequences in range of 0 to 1 by the metric name.
+// Divergences are smoothed to stay finite for texts with different vocabularies.
+func similarity(metric string) (func(tokens1, tokens2 []string) float64, error) {
+	smoothing := markov.Smoothing{Method: markov.AddK, K: 0.01}
+
+	switch metric {
+	case "markov":
+		return func(tokens1, tokens2 []string) float64 {
+			return markov.BuildChain(tokens1).Compare(markov.BuildChain(tokens2))
+		}, nil
+	case "ordered":
+		return func(tokens1, tokens2 []string) float64 {
+			return markov.BuildPositionalChain(tokens1).Compare(markov.BuildPositionalChain(tokens2))
+		}, nil
+	case "js":
+		return func(tokens1, tokens2 []string) float64 {
+			return 1 - markov.JSDivergence(markov.BuildChain(tokens1), markov.BuildChain(tokens2), smoothing)
+		}, nil
+	case "tv":
+		return func(tokens1, tokens2 []string) float64 {
+			return 1 - markov.TotalVariation(markov.BuildChain(tokens1), markov.BuildChain(tokens2), smoothing)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown metric %q", metric)
+	}
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		// The usage is already printed by the flag set.
+	case errors.Is(err, errBelowThreshold):
+		os.Exit(exitBelowThreshold)
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "compare:", err)
+		os.Exit(exitError)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+
+	var (
+		profile   = flags.String("profile", "default", "tokenizer profile: default, license, folded, multilingual or words")
+		metric    = flags.String("metric", "markov", "similarity metric: markov, ordered, js or tv")
+		order     = flags.Int("n", 2, "length of compared n-grams, at least 2")
+		threshold = flags.Float64("threshold", 0, "minimal score to exit with zero code")
+		explain   = flags.Int("explain", 0, "number of n-grams specific for each text to list")
+		diff      = flags.Bool("diff", false, "print the word level difference of the texts")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 2 {
+		return errors.New("two files are required")
+	}
+
+	if flags.Arg(0) == "-" && flags.Arg(1) == "-" {
+		return errors.New("only one file may be read from the standard input")
+	}
+
+	tokenize, err := tokenizer(*profile)
+	if err != nil {
+		return err
+	}
+
+	score, err := similarity(*metric)
+	if err != nil {
+		return err
+	}
+
+	if *order < 2 {
+		return fmt.Errorf("n-gram length must be at least 2, got %d", *order)
+	}
+
+	text1, err := readText(flags.Arg(0), stdin)
+	if err != nil {
+		return err
+	}
+
+	text2, err := readText(flags.Arg(1), stdin)
+	if err != nil {
+		return err
+	}
+
+	tokens1, tokens2 := tokenize(text1), tokenize(text2)
+	grams1, grams2 := windows(tokens1, *order-1), windows(tokens2, *order-1)
+
+	result := score(grams1, grams2)
+	fmt.Fprintf(stdout, "%.4f\n", result)
+
+	if *explain > 0 {
+		writeExplanation(stdout, grams1, grams2, *explain)
+	}
+
+	if *diff {
+		if err := writeDiff(stdout, tokens1, tokens2); err != nil {
+			return err
+		}
+	}
+
+	if result < *threshold {
+		return errBelowThreshold
+	}
+
+	return nil
+}
+
+// readText reads the file or the standard input when the name is "-".
+func readText(name string, stdin io.Reader) (string, error) {
+	var (
+		content []byte
+		err     error
+	)
+
+	if name == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(name)
+	}
+
+	return string(content), err
+}
+
+// windows joins each size consecutive tokens in an entry, so pairs of entries
+// of a chain are n-grams of size+1 tokens. Size 1 keeps the tokens as is.
+func windows(tokens []string, size int) []string {
+	if size == 1 {
+		return tokens
+	}
+
+	if len(tokens) < size {
+		return []string{}
+	}
+
+	result := make([]string, 0, len(tokens)-size+1)
+	for i := 0; i+size <= len(tokens); i++ {
+		result = append(result, strings.Join(tokens[i:i+size], " "))
+	}
+
+	return result
+}
+
+// writeExplanation lists the most frequent n-grams present only in one of the texts.
+func writeExplanation(w io.Writer, grams1, grams2 []string, limit int) {
+	chain1, chain2 := markov.BuildChain(grams1), markov.BuildChain(grams2)
+
+	fmt.Fprintf(w, "shared n-grams: %d\n", chain1.Intersect(chain2).DistinctPairs())
+
+	for _, side := range []struct {
+		title    string
+		specific *markov.Chain[string]
+	}{
+		{title: "only in first", specific: chain1.Subtract(chain2)},
+		{title: "only in second", specific: chain2.Subtract(chain1)},
+	} {
+		fmt.Fprintf(w, "%s: %d\n", side.title, side.specific.DistinctPairs())
+
+		pairs := markov.Freeze(side.specific).Pairs()
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return side.specific.Frequency(pairs[i]) > side.specific.Frequency(pairs[j])
+		})
+
+		for i, pair := range pairs {
+			if i == limit {
+				break
+			}
+
+			fmt.Fprintf(w, "  %dx %s\n", side.specific.Frequency(pair), ngram(pair))
+		}
+	}
+}
+
+// ngram restores the n-gram of a pair of overlapping windows.
+func ngram(pair markov.Pair[string]) string {
+	last := pair.Second
+	if i := strings.LastIndexByte(last, ' '); i >= 0 {
+		last = last[i+1:]
+	}
+
+	return pair.First + " " + last
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "text.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestRun(t *testing.T) {
+	original := writeFile(t, "Lorem ipsum dolor sit amet consectetur adipiscing elit")
+	changed := writeFile(t, "Lorem ipsum dolor sit amet sed do eiusmod")
+
+	t.Run("metrics", func(t *testing.T) {
+		tests := map[string]struct {
+			args     []string
+			expected string
+		}{
+			"markov":   {args: nil, expected: "0.6250\n"},
+			"trigrams": {args: []string{"-n", "3"}, expected: "0.5714\n"},
+			"ordered":  {args: []string{"-metric", "ordered"}, expected: "0.6250\n"},
+			"identity": {args: []string{"-metric", "js"}, expected: "1.0000\n"},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				var stdout bytes.Buffer
+
+				second := changed
+				if name == "identity" {
+					second = original
+				}
+
+				err := run(append(tt.args, original, second), strings.NewReader(""), &stdout)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, stdout.String())
+			})
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		err := run([]string{original, "-"}, strings.NewReader("LOREM   ipsum dolor sit amet consectetur adipiscing elit"), &stdout)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0000\n", stdout.String())
+
+		stdout.Reset()
+
+		err = run([]string{"-profile", "words", original, "-"}, strings.NewReader("LOREM   ipsum dolor sit amet consectetur adipiscing elit"), &stdout)
+		require.NoError(t, err)
+		assert.Equal(t, "0.7500\n", stdout.String())
+
+		stdout.Reset()
+
+		err = run([]string{"-profile", "folded", original, "-"}, strings.NewReader("Lorem ipsum dolor sít \u0430met consectetur adipiscing elit"), &stdout)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0000\n", stdout.String())
+
+		stdout.Reset()
+
+		err = run([]string{"-profile", "multilingual", writeFile(t, "本软件按原样提供"), "-"}, strings.NewReader("本软件按原样提供。"), &stdout)
+		require.NoError(t, err)
+		assert.Equal(t, "1.0000\n", stdout.String())
+	})
+
+	t.Run("explain_diff", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		err := run([]string{"-explain", "2", "-diff", original, changed}, strings.NewReader(""), &stdout)
+		require.NoError(t, err)
+
+		assert.Equal(t, ""+
+			"0.6250\n"+
+			"shared n-grams: 4\n"+
+			"only in first: 3\n"+
+			"  1x adipiscing elit\n"+
+			"  1x amet consectetur\n"+
+			"only in second: 3\n"+
+			"  1x amet sed\n"+
+			"  1x do eiusmod\n"+
+			"lorem ipsum dolor sit amet [-consectetur adipiscing elit-] {+sed do eiusmod+}\n",
+			stdout.String())
+	})
+
+	t.Run("threshold", func(t *testing.T) {
+		err := run([]string{"-threshold", "0.5", original, changed}, strings.NewReader(""), &bytes.Buffer{})
+		assert.NoError(t, err)
+
+		err = run([]string{"-threshold", "0.9", original, changed}, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorIs(t, err, errBelowThreshold)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, args := range [][]string{
+			{original},
+			{"-profile", "unknown", original, changed},
+			{"-metric", "unknown", original, changed},
+			{"-n", "1", original, changed},
+			{original, filepath.Join(t.TempDir(), "missing")},
+			{"-", "-"},
+		} {
+			err := run(args, strings.NewReader(""), &bytes.Buffer{})
+			assert.Error(t, err, args)
+			assert.NotErrorIs(t, err, errBelowThreshold, args)
+		}
+	})
+
+	t.Run("help", func(t *testing.T) {
+		err := run([]string{"-h"}, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorIs(t, err, flag.ErrHelp)
+	})
+}
+
+func TestDiffTokens(t *testing.T) {
+	operations, err := diffTokens([]string{"a", "b", "c", "d"}, []string{"a", "x", "c", "d", "e"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []diffOperation{
+		{kind: diffEqual, token: "a"},
+		{kind: diffRemoved, token: "b"},
+		{kind: diffAdded, token: "x"},
+		{kind: diffEqual, token: "c"},
+		{kind: diffEqual, token: "d"},
+		{kind: diffAdded, token: "e"},
+	}, operations)
+}
@@ -0,0 +1,150 @@
+// Command compared serves text comparison and matching over HTTP with JSON bodies,
+// see package server for the endpoints.
+//
+// Usage:
+//
+//	compared [flags]
+//
+// The server listens on the loopback interface by default.
+// A corpus directory of texts named by their identifiers, like MIT.txt,
+// may be loaded on start with -corpus. With -snapshot the corpora are loaded
+// from the file on start, when it exists, and saved to it on shutdown.
+// The server shuts down gracefully on SIGINT and SIGTERM.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/server"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "compared:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("compared", flag.ContinueOnError)
+
+	var (
+		addr            = flags.String("addr", "127.0.0.1:8080", "address to listen on")
+		corpus          = flags.String("corpus", "", "directory with texts loaded on start")
+		corpusName      = flags.String("corpus-name", "default", "name of the corpus loaded with -corpus")
+		snapshot        = flags.String("snapshot", "", "file the corpora are loaded from on start and saved to on shutdown")
+		maxBody         = flags.Int64("max-body", server.DefaultMaxBodySize, "maximum size of a request body in bytes")
+		shutdownTimeout = flags.Duration("shutdown-timeout", 10*time.Second, "time to finish requests in progress on shutdown")
+	)
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	handler := server.New(server.Options{MaxBodySize: *maxBody})
+
+	if *snapshot != "" {
+		if err := loadSnapshot(handler, *snapshot); err != nil {
+			return err
+		}
+	}
+
+	if *corpus != "" {
+		texts, err := compare.ReadTexts(*corpus)
+		if err != nil {
+			return fmt.Errorf("read corpus: %w", err)
+		}
+
+		handler.SetCorpus(*corpusName, texts)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	fmt.Fprintf(stdout, "listening on %s\n", listener.Addr())
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	if err := <-serveErr; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	if *snapshot != "" {
+		return saveSnapshot(handler, *snapshot)
+	}
+
+	return nil
+}
+
+func loadSnapshot(handler *server.Server, filename string) error {
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return handler.Load(file)
+}
+
+// saveSnapshot writes the snapshot to a temporary file first,
+// so a failed save doesn't destroy the previous snapshot.
+func saveSnapshot(handler *server.Server, filename string) error {
+	temporary := filename + ".tmp"
+
+	file, err := os.Create(temporary)
+	if err != nil {
+		return err
+	}
+
+	if err := handler.Save(file); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, filename)
+}
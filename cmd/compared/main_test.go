@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	snapshot := filepath.Join(t.TempDir(), "corpora.json")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stdoutReader, stdout := io.Pipe()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- run(ctx, []string{
+			"-addr", "127.0.0.1:0",
+			"-corpus", "../../testdata/licenses",
+			"-corpus-name", "licenses",
+			"-snapshot", snapshot,
+		}, stdout)
+		stdout.Close()
+	}()
+
+	line, err := bufio.NewReader(stdoutReader).ReadString('\n')
+	require.NoError(t, err)
+	go func() { _, _ = io.Copy(io.Discard, stdoutReader) }()
+
+	addr := strings.TrimSpace(strings.TrimPrefix(line, "listening on "))
+
+	response, err := http.Get("http://" + addr + "/healthz")
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	cancel()
+	require.NoError(t, <-done)
+
+	saved, err := os.ReadFile(snapshot)
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), `"licenses"`)
+	assert.Contains(t, string(saved), `"name": "MIT"`)
+}
+
+func TestRun_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Error(t, run(ctx, []string{"-unknown"}, io.Discard))
+	assert.Error(t, run(ctx, []string{"-corpus", "missing", "-addr", "127.0.0.1:0"}, io.Discard))
+
+	snapshot := filepath.Join(t.TempDir(), "corpora.json")
+	require.NoError(t, os.WriteFile(snapshot, []byte("not json"), 0o600))
+	assert.Error(t, run(ctx, []string{"-snapshot", snapshot, "-addr", "127.0.0.1:0"}, io.Discard))
+}
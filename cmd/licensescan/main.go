@@ -0,0 +1,177 @@
+// Command licensescan walks a directory tree and classifies license files
+// and source file headers against a corpus of known license texts.
+//
+// Usage:
+//
+//	licensescan -corpus <dir> [flags] [root]
+//
+// The corpus is a directory of license texts named by their identifiers, like MIT.txt.
+// Punctuation at the edges of words is not significant for matching.
+// Files ignored by .gitignore are skipped unless -no-gitignore is set.
+// Zip, jar and tar archives are scanned in memory when -archive-depth is positive,
+// files inside them are reported with paths like "deps/foo.zip!/LICENSE".
+// Archives which can't be read are reported with the unreadable-archive kind.
+// The report is a table by default, -format selects json, csv or sarif output.
+// SARIF results are created for files whose best match is a license listed in -deny.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/report"
+	"github.com/radikh/compare/scan"
+)
+
+// globsFlag collects repeated or comma separated glob flags.
+type globsFlag []string
+
+func (g *globsFlag) String() string {
+	return strings.Join(*g, ",")
+}
+
+func (g *globsFlag) Set(value string) error {
+	for _, glob := range strings.Split(value, ",") {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			*g = append(*g, glob)
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "licensescan:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("licensescan", flag.ContinueOnError)
+
+	var (
+		include, exclude globsFlag
+		deny             globsFlag
+		corpus           = flags.String("corpus", "", "directory with known license texts (required)")
+		maxSize          = flags.Int64("max-size", 1<<20, "maximum size of a scanned file in bytes, 0 for no limit")
+		threshold        = flags.Float64("threshold", 0.5, "minimal confidence of a reported match")
+		noGitignore      = flags.Bool("no-gitignore", false, "scan files ignored by .gitignore")
+		archiveDepth     = flags.Int("archive-depth", 0, "number of nested archive levels to scan, 0 to skip archives")
+		output           = flags.String("o", "", "report file, standard output by default")
+		format           = flags.String("format", "text", "report format: text, json, csv or sarif")
+	)
+
+	flags.Var(&include, "include", "glob of files to scan, may be repeated or comma separated")
+	flags.Var(&exclude, "exclude", "glob of files and directories to skip, may be repeated or comma separated")
+	flags.Var(&deny, "deny", "license reported as a SARIF result, may be repeated or comma separated")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *corpus == "" {
+		return fmt.Errorf("-corpus is required")
+	}
+
+	write, err := reportWriter(*format, deny)
+	if err != nil {
+		return err
+	}
+
+	root := "."
+	if flags.NArg() > 0 {
+		root = flags.Arg(0)
+	}
+
+	texts, err := compare.ReadTexts(*corpus)
+	if err != nil {
+		return fmt.Errorf("read corpus: %w", err)
+	}
+
+	matcher := compare.NewTextMatcherWithTokenizer(compare.LicenseTokenizer(), texts...)
+
+	scanner, err := scan.New(matcher, scan.Options{
+		Include:          include,
+		Exclude:          exclude,
+		MaxSize:          *maxSize,
+		Threshold:        *threshold,
+		DisableGitignore: *noGitignore,
+		ArchiveDepth:     *archiveDepth,
+	})
+	if err != nil {
+		return err
+	}
+
+	findings, err := scanner.Scan(root)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		w = file
+	}
+
+	return write(w, findings)
+}
+
+func reportWriter(format string, deny []string) (func(io.Writer, []scan.Finding) error, error) {
+	switch format {
+	case "text":
+		return writeTable, nil
+	case "json":
+		return report.WriteJSON, nil
+	case "csv":
+		return report.WriteCSV, nil
+	case "sarif":
+		denied := map[string]struct{}{}
+		for _, license := range deny {
+			denied[license] = struct{}{}
+		}
+
+		options := report.SARIFOptions{
+			ToolName: "licensescan",
+			Disallowed: func(license string) bool {
+				_, ok := denied[license]
+				return ok
+			},
+		}
+
+		return func(w io.Writer, findings []scan.Finding) error {
+			return report.WriteSARIF(w, findings, options)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+func writeTable(w io.Writer, findings []scan.Finding) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PATH\tLINES\tKIND\tLICENSE\tCONFIDENCE\tDECISION")
+
+	for _, finding := range findings {
+		name, confidence := "unknown", "-"
+		if best, ok := finding.Best(); ok {
+			name, confidence = best.TextName, fmt.Sprintf("%.2f", best.Confidence)
+		}
+
+		fmt.Fprintf(tw, "%s\t%d-%d\t%s\t%s\t%s\t%s\n",
+			finding.Path, finding.Region.StartLine, finding.Region.EndLine,
+			finding.Kind, name, confidence, finding.Decision)
+	}
+
+	return tw.Flush()
+}
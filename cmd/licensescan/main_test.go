@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	mit, err := os.ReadFile("../../testdata/licenses/MIT.txt")
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "LICENSE"), mit, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte("readme"), 0o600))
+
+	t.Run("stdout", func(t *testing.T) {
+		var stdout bytes.Buffer
+
+		err := run([]string{"-corpus", "../../testdata/licenses", root}, &stdout)
+		require.NoError(t, err)
+
+		assert.Equal(t, ""+
+			"PATH     LINES  KIND          LICENSE  CONFIDENCE  DECISION\n"+
+			"LICENSE  1-21   license-file  MIT      1.00        exact\n",
+			stdout.String())
+	})
+
+	t.Run("output_file", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "report.txt")
+
+		err := run([]string{"-corpus", "../../testdata/licenses", "-o", output, root}, &bytes.Buffer{})
+		require.NoError(t, err)
+
+		report, err := os.ReadFile(output)
+		require.NoError(t, err)
+		assert.Contains(t, string(report), "LICENSE  1-21")
+	})
+
+	t.Run("formats", func(t *testing.T) {
+		expected := map[string]string{
+			"json":  `"license": "MIT"`,
+			"csv":   "LICENSE,license-file,1,21,exact,MIT,1.0000",
+			"sarif": `"ruleId": "disallowed-license/MIT"`,
+		}
+
+		for format, fragment := range expected {
+			var stdout bytes.Buffer
+
+			err := run([]string{"-corpus", "../../testdata/licenses", "-format", format, "-deny", "MIT,ISC", root}, &stdout)
+			require.NoError(t, err)
+
+			assert.Contains(t, stdout.String(), fragment, format)
+		}
+	})
+
+	t.Run("unknown_format", func(t *testing.T) {
+		err := run([]string{"-corpus", "../../testdata/licenses", "-format", "xml", root}, &bytes.Buffer{})
+
+		assert.EqualError(t, err, `unknown report format "xml"`)
+	})
+
+	t.Run("missing_corpus", func(t *testing.T) {
+		err := run([]string{root}, &bytes.Buffer{})
+
+		assert.EqualError(t, err, "-corpus is required")
+	})
+}
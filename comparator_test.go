@@ -61,3 +61,19 @@ func ExampleCompareTexts() {
 	fmt.Println(result)
 	// Output: 0.72
 }
+
+func TestCompareOrdered(t *testing.T) {
+	t.Run("same_order", func(t *testing.T) {
+		t1 := "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed orci felis, placerat quis enim vitae, semper tempus erat."
+		t2 := "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed orci felis, placerat quis enim vitae, semper tempus erat. Integer non enim pharetra, molestie nulla ut."
+
+		assert.InDelta(t, CompareTexts(t1, t2), CompareTextsOrdered(t1, t2), 0.01)
+	})
+
+	t.Run("reordered_sentences", func(t *testing.T) {
+		t1 := "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed orci felis, placerat quis enim vitae, semper tempus erat. Integer non enim pharetra, molestie nulla ut."
+		t2 := "Lorem ipsum dolor sit amet, consectetur adipiscing elit. Integer non enim pharetra, molestie nulla ut. Sed orci felis, placerat quis enim vitae, semper tempus erat."
+
+		assert.Less(t, CompareTextsOrdered(t1, t2), CompareTexts(t1, t2)-0.2)
+	})
+}
@@ -9,3 +9,13 @@ func CompareTexts(t1, t2 string) float64 {
 
 	return chain1.Compare(chain2)
 }
+
+// CompareTextsOrdered returns a rate of similarity between two texts in range of 0 to 1
+// which also takes the order of the texts parts into account.
+// Texts with reordered sentences or clauses score lower than with CompareTexts.
+func CompareTextsOrdered(t1, t2 string) float64 {
+	chain1 := markov.BuildPositionalChain(Tokenize(t1))
+	chain2 := markov.BuildPositionalChain(Tokenize(t2))
+
+	return chain1.Compare(chain2)
+}
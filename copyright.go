@@ -0,0 +1,155 @@
+package compare
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// copyrightPrefixRegexp matches the beginning of a copyright statement on a line
+	// after optional comment markers.
+	copyrightPrefixRegexp = `(?i)^[\s/*#;!-]*((?:copyright\b|©|\(c\))(?:\s*(?:©|\(c\)))?)`
+	yearRangeRegexp       = `\b((?:19|20)\d{2})(?:\s*[-\x{2013}\x{2014}]\s*((?:19|20)\d{2}|present)\b)?`
+	rightsReservedRegexp  = `(?i)[\s.,;]*all rights reserved[\s.]*$`
+)
+
+// YearRange is a range of years of a copyright statement, From equals To for a single year.
+// To is zero for open ranges like "2015-present".
+type YearRange struct {
+	From int
+	To   int
+}
+
+// Copyright is a copyright statement found in a text.
+type Copyright struct {
+	// Statement is the text of the statement without comment markers.
+	Statement string
+	Years     []YearRange
+	Holder    string
+	// Start and End are byte offsets of the statement in the text.
+	Start int
+	End   int
+}
+
+// ExtractCopyrights finds copyright statements in license files and source headers.
+// A statement is a line starting with "Copyright" or "©" which contains a year
+// or a copyright sign, so sentences like "copyright notice and this permission notice"
+// are not confused with statements. A line starting with a bare "(c)" is a statement
+// only when a year follows it, as "(c)" also enumerates clauses of licenses.
+func ExtractCopyrights(text string) []Copyright {
+	prefix := regexp.MustCompile(copyrightPrefixRegexp)
+	years := regexp.MustCompile(yearRangeRegexp)
+	rightsReserved := regexp.MustCompile(rightsReservedRegexp)
+
+	result := []Copyright{}
+	offset := 0
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineStart := offset
+		offset += len(line)
+
+		line = strings.TrimRight(line, "\r\n")
+
+		match := prefix.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		statement := strings.TrimRight(line[match[2]:], " \t*/")
+		rest := statement[match[3]-match[2]:]
+
+		sign := strings.ToLower(statement[:match[3]-match[2]])
+		yearMatches := years.FindAllStringSubmatchIndex(rest, -1)
+
+		if !strings.HasPrefix(sign, "copyright") && !strings.Contains(sign, "©") {
+			// A bare "(c)" must be followed by a year.
+			if len(yearMatches) == 0 || strings.TrimLeft(rest[:yearMatches[0][0]], " \t") != "" {
+				continue
+			}
+		}
+
+		signed := strings.Contains(sign, "©") || strings.Contains(sign, "(c)")
+		if !signed && len(yearMatches) == 0 {
+			continue
+		}
+
+		copyright := Copyright{
+			Statement: statement,
+			Start:     lineStart + match[2],
+			End:       lineStart + match[2] + len(statement),
+		}
+
+		holderStart := 0
+		for _, ym := range yearMatches {
+			from, _ := strconv.Atoi(rest[ym[2]:ym[3]])
+			yearRange := YearRange{From: from, To: from}
+
+			if ym[4] >= 0 {
+				yearRange.To, _ = strconv.Atoi(rest[ym[4]:ym[5]])
+			}
+
+			copyright.Years = append(copyright.Years, yearRange)
+			holderStart = ym[1]
+		}
+
+		holder := trimRightsReserved(rest[holderStart:], rightsReserved)
+		holder = strings.TrimLeft(holder, " \t,.;:-©")
+		holder = strings.TrimPrefix(strings.TrimPrefix(holder, "(c) "), "by ")
+		copyright.Holder = strings.TrimSpace(holder)
+
+		result = append(result, copyright)
+	}
+
+	return result
+}
+
+// trimRightsReserved removes "All rights reserved" from the end of the holder.
+// The period before it is kept when it ends an abbreviation, like in "Foo Inc.".
+func trimRightsReserved(holder string, rightsReserved *regexp.Regexp) string {
+	loc := rightsReserved.FindStringIndex(holder)
+	if loc == nil {
+		return holder
+	}
+
+	trimmed := holder[:loc[0]]
+
+	words := strings.Fields(trimmed)
+	if strings.HasPrefix(holder[loc[0]:], ".") && len(words) > 0 && isCompanyAbbreviation(words[len(words)-1]) {
+		trimmed += "."
+	}
+
+	return trimmed
+}
+
+// isCompanyAbbreviation reports whether the word is an abbreviated legal form of a company,
+// which is written with a period.
+func isCompanyAbbreviation(word string) bool {
+	switch strings.ToLower(strings.TrimRight(word, ",")) {
+	case "inc", "ltd", "co", "corp", "llc", "llp", "plc", "gmbh", "ag", "bv", "s.a", "s.l", "pty", "ab", "oy", "sa":
+		return true
+	default:
+		return false
+	}
+}
+
+// StripCopyrights removes copyright statements from the text.
+// Holder names and years differ between copies of the same license,
+// so they're excluded from matching as the SPDX matching guideline 9 requires.
+func StripCopyrights(text string) string {
+	copyrights := ExtractCopyrights(text)
+	if len(copyrights) == 0 {
+		return text
+	}
+
+	var b strings.Builder
+
+	prev := 0
+	for _, copyright := range copyrights {
+		b.WriteString(text[prev:copyright.Start])
+		prev = copyright.End
+	}
+	b.WriteString(text[prev:])
+
+	return b.String()
+}
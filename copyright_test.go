@@ -0,0 +1,123 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractCopyrights(t *testing.T) {
+	testcases := map[string]struct {
+		text     string
+		expected []Copyright
+	}{
+		"go_header": {
+			text: "// Copyright 2009 The Go Authors. All rights reserved.\n// Use of this source code is governed by a BSD-style\n",
+			expected: []Copyright{{
+				Statement: "Copyright 2009 The Go Authors. All rights reserved.",
+				Years:     []YearRange{{From: 2009, To: 2009}},
+				Holder:    "The Go Authors",
+				Start:     3,
+				End:       54,
+			}},
+		},
+		"sign_and_ranges": {
+			text: "MIT License\n\nCopyright (c) 2015-2018, 2021 Jane Doe <jane@example.com>\n",
+			expected: []Copyright{{
+				Statement: "Copyright (c) 2015-2018, 2021 Jane Doe <jane@example.com>",
+				Years:     []YearRange{{From: 2015, To: 2018}, {From: 2021, To: 2021}},
+				Holder:    "Jane Doe <jane@example.com>",
+				Start:     13,
+				End:       70,
+			}},
+		},
+		"symbol_only": {
+			text: " * © Acme Inc.\n",
+			expected: []Copyright{{
+				Statement: "© Acme Inc.",
+				Holder:    "Acme Inc.",
+				Start:     3,
+				End:       15,
+			}},
+		},
+		"open_range_and_by": {
+			text: "# Copyright 2015 - present by Example Org\n",
+			expected: []Copyright{{
+				Statement: "Copyright 2015 - present by Example Org",
+				Years:     []YearRange{{From: 2015}},
+				Holder:    "Example Org",
+				Start:     2,
+				End:       41,
+			}},
+		},
+		"several": {
+			text: "Copyright 2001 A\nCopyright 2002 B\n",
+			expected: []Copyright{
+				{Statement: "Copyright 2001 A", Years: []YearRange{{From: 2001, To: 2001}}, Holder: "A", Start: 0, End: 16},
+				{Statement: "Copyright 2002 B", Years: []YearRange{{From: 2002, To: 2002}}, Holder: "B", Start: 17, End: 33},
+			},
+		},
+		"company_abbreviation": {
+			text: "Copyright (c) 2020 Foo Inc. All rights reserved.\n",
+			expected: []Copyright{{
+				Statement: "Copyright (c) 2020 Foo Inc. All rights reserved.",
+				Years:     []YearRange{{From: 2020, To: 2020}},
+				Holder:    "Foo Inc.",
+				Start:     0,
+				End:       48,
+			}},
+		},
+		"bare_sign_with_year": {
+			text: "(c) 2019 Jane Doe\n",
+			expected: []Copyright{{
+				Statement: "(c) 2019 Jane Doe",
+				Years:     []YearRange{{From: 2019, To: 2019}},
+				Holder:    "Jane Doe",
+				Start:     0,
+				End:       17,
+			}},
+		},
+		"apache_clause_is_not_statement": {
+			text: "     (c) You must retain, in the Source form of any Derivative Works\n" +
+				"         that You distribute, all copyright, patent, trademark, and\n",
+			expected: []Copyright{},
+		},
+		"license_sentence_is_not_statement": {
+			text:     "copyright notice and this permission notice appear in all copies.\nThe above copyright notice 2020.\n",
+			expected: []Copyright{},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			result := ExtractCopyrights(tc.text)
+
+			assert.Equal(t, tc.expected, result)
+
+			for _, copyright := range result {
+				assert.Equal(t, copyright.Statement, tc.text[copyright.Start:copyright.End])
+			}
+		})
+	}
+}
+
+func TestStripCopyrights(t *testing.T) {
+	text := "MIT License\n\nCopyright (c) 2021 Jane Doe\n\nPermission is hereby granted."
+
+	assert.Equal(t, "MIT License\n\n\n\nPermission is hereby granted.", StripCopyrights(text))
+	assert.Equal(t, "no statements", StripCopyrights("no statements"))
+
+	clause := "(c) You must retain, in the Source form of any Derivative Works"
+	assert.Equal(t, clause, StripCopyrights(clause))
+}
+
+func TestMatcher_IgnoresCopyrights(t *testing.T) {
+	matcher := NewTextMatcher(Text{
+		Name:    "mit",
+		Content: "Copyright (c) <year> <copyright holders>\n\nPermission is hereby granted, free of charge.",
+	})
+
+	result := matcher.Match("Copyright (c) 2015-2021 Some Very Long Company Name Ltd.\n\nPermission is hereby granted, free of charge.")
+
+	assert.Equal(t, []Match{{TextName: "mit", Confidence: 1}}, result)
+}
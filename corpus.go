@@ -0,0 +1,37 @@
+package compare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadTexts reads all regular files of the directory as texts.
+// The name of a text is the file name without extension, so a directory
+// of license texts named by SPDX identifiers like MIT.txt becomes a corpus for the TextMatcher.
+func ReadTexts(dir string) ([]Text, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]Text, 0, len(entries))
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		texts = append(texts, Text{
+			Name:    strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Content: string(content),
+		})
+	}
+
+	return texts, nil
+}
@@ -0,0 +1,29 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadTexts(t *testing.T) {
+	t.Run("licenses", func(t *testing.T) {
+		texts, err := ReadTexts("testdata/licenses")
+		require.NoError(t, err)
+
+		names := []string{}
+		for _, text := range texts {
+			names = append(names, text.Name)
+			assert.NotEmpty(t, text.Content)
+		}
+
+		assert.Equal(t, []string{"BSD-2-Clause", "ISC", "MIT"}, names)
+	})
+
+	t.Run("missing_dir", func(t *testing.T) {
+		_, err := ReadTexts("testdata/missing")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,369 @@
+// Package evaluation measures the quality of text matching on a labeled corpus.
+// It runs a matcher over files with known expected text names and reports
+// precision, recall, F1, a confusion matrix, ROC data and the worst misclassifications,
+// so thresholds can be tuned on data and guarded by regression tests.
+package evaluation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/radikh/compare"
+)
+
+// LabelsFile is the name of the manifest with labels in a corpus directory.
+// It is a JSON object which maps file paths relative to the directory
+// to lists of expected text names, an empty list means no text is expected to match.
+const LabelsFile = "labels.json"
+
+// None is the label used in the confusion matrix when nothing is expected or predicted.
+const None = "<none>"
+
+// Matcher is any matcher configuration which can be evaluated.
+type Matcher interface {
+	Match(text string) []compare.Match
+}
+
+// MatcherFunc is an adapter to use a function as a Matcher,
+// for example the MatchWeighted method of the TextMatcher.
+type MatcherFunc func(text string) []compare.Match
+
+// Match calls the function.
+func (f MatcherFunc) Match(text string) []compare.Match {
+	return f(text)
+}
+
+// Case is a labeled text.
+type Case struct {
+	File     string
+	Content  string
+	Expected []string
+}
+
+// LoadCorpus reads the labeled files of the directory listed in its labels manifest.
+func LoadCorpus(dir string) ([]Case, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, LabelsFile))
+	if err != nil {
+		return nil, err
+	}
+
+	labels := map[string][]string{}
+	if err := json.Unmarshal(manifest, &labels); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", LabelsFile, err)
+	}
+
+	files := make([]string, 0, len(labels))
+	for file := range labels {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	cases := make([]Case, 0, len(files))
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+
+		cases = append(cases, Case{
+			File:     file,
+			Content:  string(content),
+			Expected: labels[file],
+		})
+	}
+
+	return cases, nil
+}
+
+// Options configures the evaluation.
+type Options struct {
+	// Threshold is the minimal confidence of a match to be considered a prediction,
+	// matches with zero confidence are never predictions.
+	Threshold float64
+	// Thresholds are points of the ROC curve, by default from 0 to 1 with step 0.05.
+	Thresholds []float64
+	// Worst is the number of the worst misclassifications to report, by default 10.
+	Worst int
+}
+
+// ROCPoint describes the quality of predictions at a threshold.
+type ROCPoint struct {
+	Threshold         float64
+	TruePositiveRate  float64
+	FalsePositiveRate float64
+	Precision         float64
+}
+
+// Misclassification is a case with the wrong best prediction.
+type Misclassification struct {
+	File       string
+	Expected   []string
+	Predicted  string
+	Confidence float64
+}
+
+// Report contains results of the evaluation.
+type Report struct {
+	Threshold float64
+	Cases     int
+	Precision float64
+	Recall    float64
+	F1        float64
+	// Confusion counts the best predictions per expected name: Confusion[expected][predicted].
+	Confusion map[string]map[string]int
+	ROC       []ROCPoint
+	// Worst misclassifications are ordered by the confidence of the wrong prediction.
+	Worst []Misclassification
+}
+
+type scoredCase struct {
+	Case
+	expected map[string]struct{}
+	scores   map[string]float64
+	best     compare.Match
+}
+
+// Evaluate runs the matcher over the cases and reports the quality of its predictions.
+func Evaluate(matcher Matcher, cases []Case, options Options) *Report {
+	if len(options.Thresholds) == 0 {
+		for i := 0; i <= 20; i++ {
+			options.Thresholds = append(options.Thresholds, float64(i)/20)
+		}
+	}
+
+	if options.Worst == 0 {
+		options.Worst = 10
+	}
+
+	scored := make([]scoredCase, 0, len(cases))
+
+	for _, c := range cases {
+		sc := scoredCase{
+			Case:     c,
+			expected: map[string]struct{}{},
+			scores:   map[string]float64{},
+		}
+
+		for _, name := range c.Expected {
+			sc.expected[name] = struct{}{}
+		}
+
+		for _, match := range matcher.Match(c.Content) {
+			if confidence, ok := sc.scores[match.TextName]; !ok || match.Confidence > confidence {
+				sc.scores[match.TextName] = match.Confidence
+			}
+
+			if match.Confidence > sc.best.Confidence {
+				sc.best = match
+			}
+		}
+
+		scored = append(scored, sc)
+	}
+
+	report := &Report{
+		Threshold: options.Threshold,
+		Cases:     len(cases),
+		Confusion: map[string]map[string]int{},
+	}
+
+	tp, fp, fn := count(scored, options.Threshold)
+	report.Precision = ratio(tp, tp+fp)
+	report.Recall = ratio(tp, tp+fn)
+	if report.Precision+report.Recall > 0 {
+		report.F1 = 2 * report.Precision * report.Recall / (report.Precision + report.Recall)
+	}
+
+	for _, threshold := range options.Thresholds {
+		tp, fp, fn := count(scored, threshold)
+		tn := negatives(scored) - fp
+
+		report.ROC = append(report.ROC, ROCPoint{
+			Threshold:         threshold,
+			TruePositiveRate:  ratio(tp, tp+fn),
+			FalsePositiveRate: ratio(fp, fp+tn),
+			Precision:         ratio(tp, tp+fp),
+		})
+	}
+
+	for _, sc := range scored {
+		predicted := None
+		if sc.best.TextName != "" && predicts(sc.best.Confidence, options.Threshold) {
+			predicted = sc.best.TextName
+		}
+
+		expected := sc.Expected
+		if len(expected) == 0 {
+			expected = []string{None}
+		}
+
+		for _, name := range expected {
+			if report.Confusion[name] == nil {
+				report.Confusion[name] = map[string]int{}
+			}
+			report.Confusion[name][predicted]++
+		}
+
+		if _, ok := sc.expected[predicted]; ok || predicted == None && len(sc.Expected) == 0 {
+			continue
+		}
+
+		report.Worst = append(report.Worst, Misclassification{
+			File:       sc.File,
+			Expected:   sc.Expected,
+			Predicted:  predicted,
+			Confidence: sc.best.Confidence,
+		})
+	}
+
+	sort.SliceStable(report.Worst, func(i, j int) bool {
+		return report.Worst[i].Confidence > report.Worst[j].Confidence
+	})
+
+	if len(report.Worst) > options.Worst {
+		report.Worst = report.Worst[:options.Worst]
+	}
+
+	return report
+}
+
+// count returns numbers of true positive, false positive and false negative
+// predictions at the threshold across all the cases.
+func count(scored []scoredCase, threshold float64) (tp, fp, fn int) {
+	for _, sc := range scored {
+		for name, confidence := range sc.scores {
+			_, expected := sc.expected[name]
+
+			switch {
+			case predicts(confidence, threshold) && expected:
+				tp++
+			case predicts(confidence, threshold):
+				fp++
+			}
+		}
+
+		for name := range sc.expected {
+			if confidence, ok := sc.scores[name]; !ok || !predicts(confidence, threshold) {
+				fn++
+			}
+		}
+	}
+
+	return tp, fp, fn
+}
+
+// predicts reports whether a match with the confidence is a prediction at the threshold.
+// Zero confidence means no similarity at all, like in scan results.
+func predicts(confidence, threshold float64) bool {
+	return confidence > 0 && confidence >= threshold
+}
+
+// negatives returns the number of case and text pairs which are not expected to match.
+func negatives(scored []scoredCase) int {
+	result := 0
+
+	for _, sc := range scored {
+		for name := range sc.scores {
+			if _, ok := sc.expected[name]; !ok {
+				result++
+			}
+		}
+	}
+
+	return result
+}
+
+func ratio(x, y int) float64 {
+	if y == 0 {
+		return 0
+	}
+
+	return float64(x) / float64(y)
+}
+
+// Gate defines the minimal quality the report must have.
+type Gate struct {
+	Precision float64
+	Recall    float64
+	F1        float64
+}
+
+// Check returns an error describing all the metrics of the report below the gate.
+// It is meant to be used in tests as a regression gate:
+//
+//	if err := report.Check(evaluation.Gate{F1: 0.95}); err != nil {
+//		t.Fatal(err)
+//	}
+func (r *Report) Check(gate Gate) error {
+	failures := []string{}
+
+	if r.Precision < gate.Precision {
+		failures = append(failures, fmt.Sprintf("precision %.3f < %.3f", r.Precision, gate.Precision))
+	}
+
+	if r.Recall < gate.Recall {
+		failures = append(failures, fmt.Sprintf("recall %.3f < %.3f", r.Recall, gate.Recall))
+	}
+
+	if r.F1 < gate.F1 {
+		failures = append(failures, fmt.Sprintf("F1 %.3f < %.3f", r.F1, gate.F1))
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("evaluation is below the gate: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// WriteText writes the human readable report.
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "cases:\t%d\n", r.Cases)
+	fmt.Fprintf(tw, "threshold:\t%.2f\n", r.Threshold)
+	fmt.Fprintf(tw, "precision:\t%.3f\n", r.Precision)
+	fmt.Fprintf(tw, "recall:\t%.3f\n", r.Recall)
+	fmt.Fprintf(tw, "F1:\t%.3f\n", r.F1)
+
+	fmt.Fprintln(tw, "\nconfusion (expected -> predicted):")
+	for _, expected := range sortedKeys(r.Confusion) {
+		for _, predicted := range sortedKeys(r.Confusion[expected]) {
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", expected, predicted, r.Confusion[expected][predicted])
+		}
+	}
+
+	fmt.Fprintln(tw, "\nROC:")
+	fmt.Fprintln(tw, "threshold\tTPR\tFPR\tprecision")
+	for _, point := range r.ROC {
+		fmt.Fprintf(tw, "%.2f\t%.3f\t%.3f\t%.3f\n",
+			point.Threshold, point.TruePositiveRate, point.FalsePositiveRate, point.Precision)
+	}
+
+	if len(r.Worst) > 0 {
+		fmt.Fprintln(tw, "\nworst misclassifications:")
+		for _, m := range r.Worst {
+			fmt.Fprintf(tw, "%s\texpected %s\tpredicted %s\t%.3f\n",
+				m.File, strings.Join(m.Expected, ","), m.Predicted, m.Confidence)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func sortedKeys[value any](m map[string]value) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
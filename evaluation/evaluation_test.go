@@ -0,0 +1,110 @@
+package evaluation
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseMatcher(t *testing.T) *compare.TextMatcher {
+	texts, err := compare.ReadTexts("../testdata/licenses")
+	require.NoError(t, err)
+
+	return compare.NewTextMatcher(texts...)
+}
+
+func TestLoadCorpus(t *testing.T) {
+	cases, err := LoadCorpus("testdata/samples")
+	require.NoError(t, err)
+
+	files := []string{}
+	for _, c := range cases {
+		files = append(files, c.File)
+		assert.NotEmpty(t, c.Content)
+	}
+
+	assert.Equal(t, []string{"bsd_truncated.txt", "dual.txt", "isc_modified.txt", "mit.txt", "readme.txt"}, files)
+	assert.Equal(t, []string{"MIT", "ISC"}, cases[1].Expected)
+	assert.Empty(t, cases[4].Expected)
+
+	_, err = LoadCorpus("testdata/missing")
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	cases := []Case{
+		{File: "a", Content: "a", Expected: []string{"A"}},
+		{File: "b", Content: "b", Expected: []string{"B"}},
+		{File: "c", Content: "c", Expected: []string{}},
+	}
+
+	matcher := MatcherFunc(func(text string) []compare.Match {
+		switch text {
+		case "a":
+			return []compare.Match{{TextName: "A", Confidence: 0.9}, {TextName: "B", Confidence: 0.2}}
+		case "b":
+			return []compare.Match{{TextName: "A", Confidence: 0.7}, {TextName: "B", Confidence: 0.4}}
+		default:
+			return []compare.Match{{TextName: "A", Confidence: 0.1}, {TextName: "B", Confidence: 0.6}}
+		}
+	})
+
+	report := Evaluate(matcher, cases, Options{Threshold: 0.5, Thresholds: []float64{0, 0.5, 1}})
+
+	// A for a is the only true positive, A for b and B for c are false positives, B for b is missed.
+	assert.InDelta(t, 1./3., report.Precision, 1e-9)
+	assert.InDelta(t, 1./2., report.Recall, 1e-9)
+	assert.InDelta(t, 0.4, report.F1, 1e-9)
+
+	assert.Equal(t, map[string]map[string]int{
+		"A":  {"A": 1},
+		"B":  {"A": 1},
+		None: {"B": 1},
+	}, report.Confusion)
+
+	assert.Equal(t, []ROCPoint{
+		{Threshold: 0, TruePositiveRate: 1, FalsePositiveRate: 1, Precision: 2. / 6.},
+		{Threshold: 0.5, TruePositiveRate: 0.5, FalsePositiveRate: 0.5, Precision: 1. / 3.},
+		{Threshold: 1, TruePositiveRate: 0, FalsePositiveRate: 0, Precision: 0},
+	}, report.ROC)
+
+	assert.Equal(t, []Misclassification{
+		{File: "b", Expected: []string{"B"}, Predicted: "A", Confidence: 0.7},
+		{File: "c", Expected: []string{}, Predicted: "B", Confidence: 0.6},
+	}, report.Worst)
+
+	assert.Error(t, report.Check(Gate{F1: 0.5}))
+	assert.NoError(t, report.Check(Gate{Precision: 0.3, Recall: 0.5}))
+}
+
+func TestEvaluate_ZeroConfidence(t *testing.T) {
+	cases := []Case{{File: "a", Content: "a", Expected: []string{"A"}}}
+
+	matcher := MatcherFunc(func(string) []compare.Match {
+		return []compare.Match{{TextName: "A", Confidence: 0.8}, {TextName: "B", Confidence: 0}}
+	})
+
+	report := Evaluate(matcher, cases, Options{Thresholds: []float64{0}})
+
+	assert.Equal(t, 1., report.Precision)
+	assert.Equal(t, 1., report.Recall)
+	assert.Equal(t, []ROCPoint{{Threshold: 0, TruePositiveRate: 1, FalsePositiveRate: 0, Precision: 1}}, report.ROC)
+	assert.Empty(t, report.Worst)
+}
+
+// TestLicensesRegression is the regression gate of the license matching quality.
+func TestLicensesRegression(t *testing.T) {
+	cases, err := LoadCorpus("testdata/samples")
+	require.NoError(t, err)
+
+	report := Evaluate(licenseMatcher(t), cases, Options{Threshold: 0.3})
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	t.Log("\n" + buf.String())
+
+	assert.NoError(t, report.Check(Gate{Precision: 1, Recall: 1, F1: 1}))
+}
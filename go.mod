@@ -2,10 +2,14 @@ module github.com/radikh/compare
 
 go 1.20
 
+require (
+	github.com/rivo/uniseg v0.4.7
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/text v0.22.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/objx v0.5.0 // indirect
-	github.com/stretchr/testify v1.8.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
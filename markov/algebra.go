@@ -0,0 +1,128 @@
+package markov
+
+// Merge creates a new chain which contains pairs of both chains with summed counts.
+// The words count is the sum of words counts of the chains.
+// The result starts with the first word of the caller and ends with the last word
+// of the merged chain, so appending to it continues the merged chain.
+func (c *Chain[entry]) Merge(merged *Chain[entry]) *Chain[entry] {
+	result := &Chain[entry]{
+		stats:        make(map[Pair[entry]]int, max(len(c.stats), len(merged.stats))),
+		wordsCount:   c.wordsCount + merged.wordsCount,
+		firstWord:    c.firstWord,
+		lastWord:     merged.lastWord,
+		hasFirstWord: c.hasFirstWord,
+	}
+
+	if c.wordsCount == 0 {
+		result.firstWord = merged.firstWord
+		result.hasFirstWord = merged.hasFirstWord
+	}
+
+	if merged.wordsCount == 0 {
+		result.lastWord = c.lastWord
+	}
+
+	for pair, count := range c.stats {
+		result.stats[pair] += count
+	}
+
+	for pair, count := range merged.stats {
+		result.stats[pair] += count
+	}
+
+	return result
+}
+
+// Subtract creates a new chain with pairs of the caller which are left
+// after removing pairs of the subtracted chain, counts are decreased accordingly.
+// It isolates what the caller adds to the subtracted chain.
+func (c *Chain[entry]) Subtract(subtracted *Chain[entry]) *Chain[entry] {
+	stats := map[Pair[entry]]int{}
+
+	for pair, count := range c.stats {
+		if rest := count - subtracted.stats[pair]; rest > 0 {
+			stats[pair] = rest
+		}
+	}
+
+	return c.derive(stats)
+}
+
+// Intersect creates a new chain with pairs present in both chains,
+// each pair has the smaller of the two counts.
+func (c *Chain[entry]) Intersect(intersected *Chain[entry]) *Chain[entry] {
+	stats := map[Pair[entry]]int{}
+
+	for pair, count := range c.stats {
+		if common := min(count, intersected.stats[pair]); common > 0 {
+			stats[pair] = common
+		}
+	}
+
+	return c.derive(stats)
+}
+
+// derive creates a chain from the stats calculated on the base of the caller.
+// Such a chain has no source sequence, so its words count is the number of pairs plus one,
+// as in a sequence which has each pair once, though no such sequence may exist.
+// First and last words of the caller are kept when remaining pairs start or end with them,
+// otherwise they are the only word which starts more pairs than ends or the only word
+// which ends more pairs than starts, and they are left empty when there is no such word.
+// A chain without the first word doesn't get the first word match in Compare.
+func (c *Chain[entry]) derive(stats map[Pair[entry]]int) *Chain[entry] {
+	result := &Chain[entry]{
+		stats: stats,
+	}
+
+	if len(stats) == 0 {
+		return result
+	}
+
+	result.wordsCount = 1
+
+	balance := map[entry]int{}
+	starts, ends := false, false
+
+	for pair, count := range stats {
+		result.wordsCount += count
+
+		balance[pair.First] += count
+		balance[pair.Second] -= count
+
+		starts = starts || c.hasFirstWord && pair.First == c.firstWord
+		ends = ends || pair.Second == c.lastWord
+	}
+
+	if starts {
+		result.firstWord, result.hasFirstWord = c.firstWord, true
+	} else if word, ok := onlyWord(balance, func(b int) bool { return b > 0 }); ok {
+		result.firstWord, result.hasFirstWord = word, true
+	}
+
+	if ends {
+		result.lastWord = c.lastWord
+	} else if word, ok := onlyWord(balance, func(b int) bool { return b < 0 }); ok {
+		result.lastWord = word
+	}
+
+	return result
+}
+
+// onlyWord returns the word which balance satisfies the condition, ok is false
+// when there is no such word or there are several.
+func onlyWord[entry comparable](balance map[entry]int, condition func(int) bool) (word entry, ok bool) {
+	for w, b := range balance {
+		if !condition(b) {
+			continue
+		}
+
+		if ok {
+			var zero entry
+			return zero, false
+		}
+
+		word, ok = w, true
+	}
+
+	return word, ok
+}
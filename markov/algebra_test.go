@@ -0,0 +1,146 @@
+package markov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_Append(t *testing.T) {
+	t.Run("equals_to_built_at_once", func(t *testing.T) {
+		words := dummyWords()
+
+		chain := BuildChain([]string{})
+		chain.Append(words[:1]...)
+		chain.Append(words[1:7]...)
+		chain.Append()
+		chain.Append(words[7:]...)
+
+		assert.Equal(t, dummyChain(), chain)
+	})
+
+	t.Run("accessors", func(t *testing.T) {
+		chain := dummyChain()
+
+		assert.Equal(t, 15, chain.WordsCount())
+		assert.Equal(t, 10, chain.DistinctPairs())
+		assert.Equal(t, 2, chain.Frequency(Pair[string]{First: "sit", Second: "Lorem"}))
+		assert.Equal(t, 0, chain.Frequency(Pair[string]{First: "sit", Second: "amet"}))
+	})
+}
+
+func TestChain_Merge(t *testing.T) {
+	t.Run("sums_counts", func(t *testing.T) {
+		left := BuildChain([]string{"Lorem", "ipsum", "dolor"})
+		right := BuildChain([]string{"Lorem", "ipsum", "sit"})
+
+		expected := &Chain[string]{
+			stats: map[Pair[string]]int{
+				{First: "Lorem", Second: "ipsum"}: 2,
+				{First: "ipsum", Second: "dolor"}: 1,
+				{First: "ipsum", Second: "sit"}:   1,
+			},
+			wordsCount:   6,
+			firstWord:    "Lorem",
+			lastWord:     "sit",
+			hasFirstWord: true,
+		}
+
+		assert.Equal(t, expected, left.Merge(right))
+	})
+
+	t.Run("left_empty", func(t *testing.T) {
+		left := BuildChain([]string{})
+
+		result := left.Merge(dummyChain())
+
+		assert.Equal(t, dummyChain(), result)
+	})
+
+	t.Run("right_empty", func(t *testing.T) {
+		right := BuildChain([]string{})
+
+		result := dummyChain().Merge(right)
+
+		assert.Equal(t, dummyChain(), result)
+	})
+}
+
+func TestChain_Subtract(t *testing.T) {
+	t.Run("isolates_addition", func(t *testing.T) {
+		modified := BuildChain([]string{"Lorem", "ipsum", "dolor", "sit", "amet"})
+		original := BuildChain([]string{"Lorem", "ipsum", "dolor"})
+
+		expected := &Chain[string]{
+			stats: map[Pair[string]]int{
+				{First: "dolor", Second: "sit"}: 1,
+				{First: "sit", Second: "amet"}:  1,
+			},
+			wordsCount:   3,
+			firstWord:    "dolor",
+			lastWord:     "amet",
+			hasFirstWord: true,
+		}
+
+		assert.Equal(t, expected, modified.Subtract(original))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		modified := BuildChain([]string{"Lorem", "ipsum", "dolor", "ipsum"})
+		original := BuildChain([]string{"Lorem", "ipsum"})
+
+		result := modified.Subtract(original)
+
+		assert.Equal(t, 3, result.WordsCount())
+		assert.Equal(t, "", result.firstWord)
+		assert.False(t, result.hasFirstWord)
+		assert.Equal(t, "ipsum", result.lastWord)
+	})
+
+	t.Run("no_first_word_against_empty", func(t *testing.T) {
+		modified := BuildChain([]string{"Lorem", "ipsum", "dolor", "ipsum"})
+		original := BuildChain([]string{"Lorem", "ipsum"})
+		empty := BuildChain([]string{})
+
+		result := modified.Subtract(original)
+
+		assert.Equal(t, 0., result.Compare(empty))
+		assert.Equal(t, 0., Freeze(result).Compare(Freeze(empty)))
+	})
+
+	t.Run("self", func(t *testing.T) {
+		chain := dummyChain()
+
+		expected := &Chain[string]{
+			stats: map[Pair[string]]int{},
+		}
+
+		assert.Equal(t, expected, chain.Subtract(chain))
+	})
+}
+
+func TestChain_Intersect(t *testing.T) {
+	t.Run("takes_min_counts", func(t *testing.T) {
+		left := BuildChain([]string{"Lorem", "ipsum", "Lorem", "ipsum", "dolor"})
+		right := BuildChain([]string{"Lorem", "ipsum", "sit"})
+
+		expected := &Chain[string]{
+			stats: map[Pair[string]]int{
+				{First: "Lorem", Second: "ipsum"}: 1,
+			},
+			wordsCount:   2,
+			firstWord:    "Lorem",
+			lastWord:     "ipsum",
+			hasFirstWord: true,
+		}
+
+		assert.Equal(t, expected, left.Intersect(right))
+	})
+
+	t.Run("no_intersection", func(t *testing.T) {
+		left := BuildChain([]string{"Lorem", "ipsum"})
+		right := BuildChain([]string{"dolor", "sit"})
+
+		assert.Equal(t, 0, left.Intersect(right).WordsCount())
+	})
+}
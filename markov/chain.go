@@ -12,6 +12,9 @@ type Chain[entry comparable] struct {
 	stats      map[Pair[entry]]int
 	wordsCount int
 	firstWord  entry
+	lastWord   entry
+	// hasFirstWord is false for empty chains and derived chains with an unknown first word.
+	hasFirstWord bool
 }
 
 // BuildChain creates build an instance of markov chain.
@@ -19,34 +22,61 @@ type Chain[entry comparable] struct {
 // The function will not consider adding any leading or trailing strings.
 // There is no any special or reserved words, so any string can be passed in the words slice.
 func BuildChain[entry comparable](words []entry) *Chain[entry] {
-	stats := map[Pair[entry]]int{}
-
 	chain := &Chain[entry]{
-		stats:      stats,
-		wordsCount: len(words),
+		stats: map[Pair[entry]]int{},
 	}
 
-	if chain.wordsCount == 0 {
-		return chain
+	chain.Append(words...)
+
+	return chain
+}
+
+// Append continues the chain with words as if they were added
+// to the end of the sequence the chain was built from.
+func (c *Chain[entry]) Append(words ...entry) {
+	if len(words) == 0 {
+		return
 	}
 
-	chain.firstWord = words[0]
+	if c.wordsCount == 0 {
+		c.firstWord = words[0]
+		c.hasFirstWord = true
+		c.lastWord = words[0]
+		c.wordsCount++
+		words = words[1:]
+	}
 
-	prev := chain.firstWord
-	for _, word := range words[1:] {
+	prev := c.lastWord
+	for _, word := range words {
 		pair := Pair[entry]{First: prev, Second: word}
-		stats[pair]++
+		c.stats[pair]++
 		prev = word
 	}
 
-	return chain
+	c.lastWord = prev
+	c.wordsCount += len(words)
+}
+
+// WordsCount returns the number of words the chain was built from.
+func (c *Chain[entry]) WordsCount() int {
+	return c.wordsCount
+}
+
+// DistinctPairs returns the number of unique pairs in the chain.
+func (c *Chain[entry]) DistinctPairs() int {
+	return len(c.stats)
+}
+
+// Frequency returns how many times the pair occurs in the chain.
+func (c *Chain[entry]) Frequency(pair Pair[entry]) int {
+	return c.stats[pair]
 }
 
 // Compare matches the chain to the compared one.
 // It iterates over reflections of pairs of words to consequent words list and counts
 // number of pairs from the left chain (the caller) appears in the right chain (the compared one).
 // The result score is the number of matches divided on number of pairs in the left chain,
-// it is in bounds of 0 and 1.
+// it is in bounds of 0 and 1. First words match only when both chains have one.
 func (c *Chain[entry]) Compare(compared *Chain[entry]) float64 {
 	if c.wordsCount == 0 {
 		return 0
@@ -54,15 +84,12 @@ func (c *Chain[entry]) Compare(compared *Chain[entry]) float64 {
 
 	totalMatches := 0
 
-	if c.firstWord == compared.firstWord {
+	if c.hasFirstWord && compared.hasFirstWord && c.firstWord == compared.firstWord {
 		totalMatches++
 	}
 
-	usedKeys := map[Pair[entry]]struct{}{}
-
 	for pair, count := range c.stats {
 		totalMatches += min(count, compared.stats[pair])
-		usedKeys[pair] = struct{}{}
 	}
 
 	return float64(totalMatches) / float64(max(c.wordsCount, compared.wordsCount))
@@ -42,8 +42,10 @@ func dummyChain() *Chain[string] {
 			{First: "lorem", Second: "lorem"}: 1,
 			{First: "sit", Second: "Lorem"}:   2,
 		},
-		wordsCount: 15,
-		firstWord:  "Lorem",
+		wordsCount:   15,
+		firstWord:    "Lorem",
+		lastWord:     "amet,",
+		hasFirstWord: true,
 	}
 }
 
@@ -74,9 +76,11 @@ func TestBuildChain(t *testing.T) {
 		words := []string{"Lorem"}
 
 		expected := &Chain[string]{
-			stats:      map[Pair[string]]int{},
-			wordsCount: 1,
-			firstWord:  "Lorem",
+			stats:        map[Pair[string]]int{},
+			wordsCount:   1,
+			firstWord:    "Lorem",
+			lastWord:     "Lorem",
+			hasFirstWord: true,
 		}
 
 		result := BuildChain(words)
@@ -100,8 +104,11 @@ func TestBuildChain(t *testing.T) {
 			stats: map[Pair[string]]int{
 				{First: "Lorem", Second: "Lorem"}: 7,
 			},
-			wordsCount: 8,
-			firstWord:  "Lorem"}
+			wordsCount:   8,
+			firstWord:    "Lorem",
+			lastWord:     "Lorem",
+			hasFirstWord: true,
+		}
 
 		result := BuildChain(words)
 
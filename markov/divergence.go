@@ -0,0 +1,92 @@
+package markov
+
+import "math"
+
+// KLDivergence returns the Kullback-Leibler divergence of the q chain transitions
+// from the p chain transitions in bits.
+// Both chains are smoothed over the common vocabulary and the divergences
+// of the conditional distributions P(next|prev) are averaged with weights
+// of how often the prev word is followed by any word in both chains.
+// The divergence is not symmetric and is infinite when q misses transitions of p,
+// use a smoothing with non-zero pseudo count or discount to avoid it.
+func KLDivergence[entry Ordered](p, q *Chain[entry], smoothing Smoothing) float64 {
+	return divergence(p, q, smoothing, func(pp, qp float64) float64 {
+		return klTerm(pp, qp)
+	})
+}
+
+// JSDivergence returns the Jensen-Shannon divergence between the chains transitions in bits.
+// It is symmetric and is in bounds of 0 and 1.
+func JSDivergence[entry Ordered](p, q *Chain[entry], smoothing Smoothing) float64 {
+	return divergence(p, q, smoothing, func(pp, qp float64) float64 {
+		mp := (pp + qp) / 2
+		return (klTerm(pp, mp) + klTerm(qp, mp)) / 2
+	})
+}
+
+// TotalVariation returns the total variation distance between the chains transitions.
+// It is symmetric and is in bounds of 0 and 1.
+func TotalVariation[entry Ordered](p, q *Chain[entry], smoothing Smoothing) float64 {
+	return divergence(p, q, smoothing, func(pp, qp float64) float64 {
+		return math.Abs(pp-qp) / 2
+	})
+}
+
+// divergence sums the term over the transitions of the chains models
+// built on the common vocabulary.
+func divergence[entry Ordered](p, q *Chain[entry], smoothing Smoothing, term func(pp, qp float64) float64) float64 {
+	frozenP, frozenQ := Freeze(p), Freeze(q)
+	words := vocabulary(frozenP, frozenQ)
+
+	modelP := newModel(frozenP, smoothing, words)
+	modelQ := newModel(frozenQ, smoothing, words)
+
+	total := 0
+	for _, word := range words {
+		total += modelP.outgoing[word] + modelQ.outgoing[word]
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	result := 0.0
+
+	for _, prev := range words {
+		weight := float64(modelP.outgoing[prev]+modelQ.outgoing[prev]) / float64(total)
+		if weight == 0 {
+			continue
+		}
+
+		sum := 0.0
+		unknownP, unknownQ := 1.0, 1.0
+
+		for _, next := range words {
+			pp := modelP.Probability(prev, next)
+			qp := modelQ.Probability(prev, next)
+
+			sum += term(pp, qp)
+			unknownP -= pp
+			unknownQ -= qp
+		}
+
+		// The rest of the mass belongs to the unknown word slot.
+		sum += term(math.Max(unknownP, 0), math.Max(unknownQ, 0))
+
+		result += weight * sum
+	}
+
+	return result
+}
+
+func klTerm(p, q float64) float64 {
+	if p <= 0 {
+		return 0
+	}
+
+	if q <= 0 {
+		return math.Inf(1)
+	}
+
+	return p * math.Log2(p/q)
+}
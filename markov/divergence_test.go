@@ -0,0 +1,62 @@
+package markov
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDivergences(t *testing.T) {
+	smoothing := Smoothing{Method: KneserNey, Discount: 0.75}
+
+	t.Run("self", func(t *testing.T) {
+		chain := BuildChain(dummyWords())
+
+		assert.InDelta(t, 0, KLDivergence(chain, chain, smoothing), delta)
+		assert.InDelta(t, 0, JSDivergence(chain, chain, smoothing), delta)
+		assert.InDelta(t, 0, TotalVariation(chain, chain, smoothing), delta)
+	})
+
+	t.Run("symmetric_and_bounded", func(t *testing.T) {
+		p := BuildChain(dummyWords())
+		q := BuildChain([]string{"Lorem", "ipsum", "dolor", "sit", "amet"})
+
+		js := JSDivergence(p, q, smoothing)
+		tv := TotalVariation(p, q, smoothing)
+
+		assert.InDelta(t, js, JSDivergence(q, p, smoothing), delta)
+		assert.InDelta(t, tv, TotalVariation(q, p, smoothing), delta)
+
+		assert.Greater(t, js, 0.)
+		assert.LessOrEqual(t, js, 1.)
+		assert.Greater(t, tv, 0.)
+		assert.LessOrEqual(t, tv, 1.)
+	})
+
+	t.Run("disjoint_chains_are_far", func(t *testing.T) {
+		p := BuildChain([]string{"a", "b", "a", "b"})
+		q := BuildChain([]string{"c", "d", "c", "d"})
+		r := BuildChain([]string{"a", "b", "a", "c"})
+
+		assert.Greater(t, JSDivergence(p, q, smoothing), JSDivergence(p, r, smoothing))
+		assert.Greater(t, TotalVariation(p, q, smoothing), TotalVariation(p, r, smoothing))
+	})
+
+	t.Run("unsmoothed_missing_transition", func(t *testing.T) {
+		p := BuildChain([]string{"a", "b"})
+		q := BuildChain([]string{"a", "c"})
+
+		mle := Smoothing{Method: AddK}
+
+		assert.True(t, math.IsInf(KLDivergence(p, q, mle), 1))
+		assert.InDelta(t, 1, JSDivergence(p, q, mle), delta)
+		assert.InDelta(t, 1, TotalVariation(p, q, mle), delta)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		empty := BuildChain([]string{})
+
+		assert.InDelta(t, 0, JSDivergence(empty, empty, smoothing), delta)
+	})
+}
@@ -0,0 +1,160 @@
+package markov
+
+import "sort"
+
+// Ordered is a constraint for entries that can be sorted.
+// Frozen chains keep their pairs sorted, so they require ordered entries.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// FrozenChain is an immutable form of the Chain.
+// Pairs and their counts are stored in sorted packed arrays,
+// so two frozen chains are compared with a linear merge instead of map lookups.
+type FrozenChain[entry Ordered] struct {
+	pairs        []Pair[entry]
+	counts       []int
+	wordsCount   int
+	firstWord    entry
+	hasFirstWord bool
+}
+
+// Freeze creates an immutable sorted copy of the chain.
+// Later changes of the chain do not affect the frozen one.
+func Freeze[entry Ordered](c *Chain[entry]) *FrozenChain[entry] {
+	frozen := &FrozenChain[entry]{
+		pairs:        make([]Pair[entry], 0, len(c.stats)),
+		counts:       make([]int, len(c.stats)),
+		wordsCount:   c.wordsCount,
+		firstWord:    c.firstWord,
+		hasFirstWord: c.hasFirstWord,
+	}
+
+	for pair := range c.stats {
+		frozen.pairs = append(frozen.pairs, pair)
+	}
+
+	sort.Slice(frozen.pairs, func(i, j int) bool {
+		return lessPair(frozen.pairs[i], frozen.pairs[j])
+	})
+
+	for i, pair := range frozen.pairs {
+		frozen.counts[i] = c.stats[pair]
+	}
+
+	return frozen
+}
+
+// Compare matches the frozen chain to the compared one.
+// The score is the same as Chain.Compare returns for the original chains.
+func (c *FrozenChain[entry]) Compare(compared *FrozenChain[entry]) float64 {
+	if c.wordsCount == 0 {
+		return 0
+	}
+
+	totalMatches := 0
+
+	if c.hasFirstWord && compared.hasFirstWord && c.firstWord == compared.firstWord {
+		totalMatches++
+	}
+
+	i, j := 0, 0
+	for i < len(c.pairs) && j < len(compared.pairs) {
+		switch {
+		case lessPair(c.pairs[i], compared.pairs[j]):
+			i++
+		case lessPair(compared.pairs[j], c.pairs[i]):
+			j++
+		default:
+			totalMatches += min(c.counts[i], compared.counts[j])
+			i++
+			j++
+		}
+	}
+
+	return float64(totalMatches) / float64(max(c.wordsCount, compared.wordsCount))
+}
+
+func lessPair[entry Ordered](a, b Pair[entry]) bool {
+	if a.First != b.First {
+		return a.First < b.First
+	}
+	return a.Second < b.Second
+}
+
+// Pairs returns sorted unique pairs of the chain.
+func (c *FrozenChain[entry]) Pairs() []Pair[entry] {
+	return append([]Pair[entry](nil), c.pairs...)
+}
+
+// WeightedCompare matches the frozen chain to the compared one where each pair
+// contributes to the score with its weight instead of one.
+// The result score is the weighted number of matching pairs divided on the bigger
+// weighted number of pairs of the chains, it is in bounds of 0 and 1.
+// Unlike Compare it doesn't take the first word into account.
+func (c *FrozenChain[entry]) WeightedCompare(compared *FrozenChain[entry], weight func(Pair[entry]) float64) float64 {
+	totalMatches := 0.0
+	leftTotal, rightTotal := 0.0, 0.0
+
+	i, j := 0, 0
+	for i < len(c.pairs) || j < len(compared.pairs) {
+		switch {
+		case j == len(compared.pairs) || i < len(c.pairs) && lessPair(c.pairs[i], compared.pairs[j]):
+			leftTotal += float64(c.counts[i]) * weight(c.pairs[i])
+			i++
+		case i == len(c.pairs) || lessPair(compared.pairs[j], c.pairs[i]):
+			rightTotal += float64(compared.counts[j]) * weight(compared.pairs[j])
+			j++
+		default:
+			w := weight(c.pairs[i])
+			leftTotal += float64(c.counts[i]) * w
+			rightTotal += float64(compared.counts[j]) * w
+			totalMatches += float64(min(c.counts[i], compared.counts[j])) * w
+			i++
+			j++
+		}
+	}
+
+	total := leftTotal
+	if rightTotal > total {
+		total = rightTotal
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return totalMatches / total
+}
+
+// Containment returns the share of the chain pairs occurrences which are present
+// in the compared chain, it is in bounds of 0 and 1. Unlike Compare it is not symmetric:
+// a chain of a text contained in a longer text scores 1 against the longer one.
+func (c *FrozenChain[entry]) Containment(compared *FrozenChain[entry]) float64 {
+	totalMatches, total := 0, 0
+
+	i, j := 0, 0
+	for i < len(c.pairs) {
+		switch {
+		case j == len(compared.pairs) || lessPair(c.pairs[i], compared.pairs[j]):
+			total += c.counts[i]
+			i++
+		case lessPair(compared.pairs[j], c.pairs[i]):
+			j++
+		default:
+			total += c.counts[i]
+			totalMatches += min(c.counts[i], compared.counts[j])
+			i++
+			j++
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return float64(totalMatches) / float64(total)
+}
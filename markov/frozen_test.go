@@ -0,0 +1,149 @@
+package markov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFreeze(t *testing.T) {
+	t.Run("many_words", func(t *testing.T) {
+		expected := &FrozenChain[string]{
+			pairs: []Pair[string]{
+				{First: "Lorem", Second: "amet,"},
+				{First: "Lorem", Second: "ipsum"},
+				{First: "amet", Second: "sit"},
+				{First: "amet,", Second: "ipsum"},
+				{First: "dolor", Second: "amet"},
+				{First: "ipsum", Second: "dolor"},
+				{First: "ipsum", Second: "lorem"},
+				{First: "lorem", Second: "dolor"},
+				{First: "lorem", Second: "lorem"},
+				{First: "sit", Second: "Lorem"},
+			},
+			counts:       []int{2, 1, 2, 1, 2, 1, 1, 1, 1, 2},
+			wordsCount:   15,
+			firstWord:    "Lorem",
+			hasFirstWord: true,
+		}
+
+		result := Freeze(dummyChain())
+
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("zero_words", func(t *testing.T) {
+		expected := &FrozenChain[string]{
+			pairs:  []Pair[string]{},
+			counts: []int{},
+		}
+
+		result := Freeze(BuildChain([]string{}))
+
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestFrozenChain_Compare(t *testing.T) {
+	testcases := map[string]struct {
+		comparing, compared []string
+	}{
+		"self": {
+			comparing: dummyWords(),
+			compared:  dummyWords(),
+		},
+		"left_empty": {
+			comparing: []string{},
+			compared:  dummyWords(),
+		},
+		"right_empty": {
+			comparing: dummyWords(),
+			compared:  []string{},
+		},
+		"both_empty": {
+			comparing: []string{},
+			compared:  []string{},
+		},
+		"one_word_match": {
+			comparing: []string{"Lorem", "ipsum", "sit", "dolor"},
+			compared:  []string{"Dolor", "sit", "Lorem", "ipsum"},
+		},
+		"compared_is_bigger": {
+			comparing: []string{"Lorem", "ipsum", "dolor", "amet", "sit", "Lorem", "amet,", "ipsum"},
+			compared:  dummyWords(),
+		},
+		"compared_has_more_chain_series": {
+			comparing: []string{"lorem", "ipsum", "amet", "sit", "Dolor"},
+			compared: []string{
+				"lorem", "ipsum", "amet", "sit", "Dolor",
+				"lorem", "ipsum", "amet", "sit", "Dolor",
+				"lorem", "ipsum", "amet", "sit", "Dolor",
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			comparing := BuildChain(tc.comparing)
+			compared := BuildChain(tc.compared)
+
+			expected := comparing.Compare(compared)
+
+			confidence := Freeze(comparing).Compare(Freeze(compared))
+
+			assert.InDelta(t, expected, confidence, delta)
+		})
+	}
+}
+
+func TestFrozenChain_WeightedCompare(t *testing.T) {
+	one := func(Pair[string]) float64 { return 1 }
+
+	t.Run("self", func(t *testing.T) {
+		chain := Freeze(dummyChain())
+
+		assert.InDelta(t, 1, chain.WeightedCompare(chain, one), delta)
+	})
+
+	t.Run("unit_weights", func(t *testing.T) {
+		comparing := Freeze(BuildChain([]string{"Lorem", "ipsum", "sit", "dolor"}))
+		compared := Freeze(BuildChain([]string{"Lorem", "ipsum", "sit", "lorem", "amet"}))
+
+		assert.InDelta(t, 2./4., comparing.WeightedCompare(compared, one), delta)
+	})
+
+	t.Run("distinctive_pair_dominates", func(t *testing.T) {
+		comparing := Freeze(BuildChain([]string{"of", "the", "affero", "general"}))
+		compared := Freeze(BuildChain([]string{"of", "the", "lesser", "general"}))
+
+		weight := func(pair Pair[string]) float64 {
+			if pair.First == "of" {
+				return 0.1
+			}
+			return 1
+		}
+
+		assert.InDelta(t, 0.1/2.1, comparing.WeightedCompare(compared, weight), delta)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		empty := Freeze(BuildChain([]string{}))
+
+		assert.InDelta(t, 0, empty.WeightedCompare(Freeze(dummyChain()), one), delta)
+	})
+}
+
+func TestFrozenChain_Containment(t *testing.T) {
+	part := Freeze(BuildChain([]string{"Lorem", "ipsum", "dolor", "Lorem", "ipsum"}))
+	whole := Freeze(BuildChain([]string{"sit", "Lorem", "ipsum", "dolor", "Lorem", "ipsum", "amet"}))
+
+	assert.InDelta(t, 1, part.Containment(whole), delta)
+	assert.InDelta(t, 4./6., whole.Containment(part), delta)
+
+	other := Freeze(BuildChain([]string{"Lorem", "ipsum", "amet"}))
+	assert.InDelta(t, 1./4., part.Containment(other), delta)
+
+	empty := Freeze(BuildChain([]string{}))
+	assert.InDelta(t, 0, empty.Containment(whole), delta)
+	assert.InDelta(t, 0, whole.Containment(empty), delta)
+}
@@ -0,0 +1,205 @@
+package markov
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// SmoothingMethod defines how probability mass is reserved for unseen transitions.
+type SmoothingMethod int
+
+const (
+	// AddK adds a pseudo count to every possible transition.
+	// K equal to 1 is the Laplace smoothing, K equal to 0 is the maximum likelihood estimation.
+	AddK SmoothingMethod = iota
+	// KneserNey is the interpolated Kneser-Ney smoothing which discounts observed transitions
+	// and spreads the mass according to how many different words precede the next word.
+	KneserNey
+)
+
+// Smoothing configures a smoothing method of the Model.
+type Smoothing struct {
+	Method SmoothingMethod
+	// K is a pseudo count used by AddK method.
+	K float64
+	// Discount is subtracted from each observed transition count by KneserNey method,
+	// it should be in bounds of 0 and 1.
+	Discount float64
+}
+
+// Model is a probabilistic view of a chain.
+// It treats pair counts as observations of transitions between words
+// and estimates conditional probabilities P(next|prev) with a smoothing.
+// The vocabulary of the model contains all words of the chain and a single slot
+// for unknown words, so smoothed probabilities of the unseen words are above zero.
+type Model[entry Ordered] struct {
+	smoothing  Smoothing
+	vocabulary []entry
+	firstWord  entry
+	wordsCount int
+
+	transitions   map[Pair[entry]]int
+	outgoing      map[entry]int
+	successors    map[entry]int
+	predecessors  map[entry]int
+	distinctPairs int
+}
+
+// NewModel creates a model of the chain with the smoothing.
+func NewModel[entry Ordered](chain *Chain[entry], smoothing Smoothing) *Model[entry] {
+	frozen := Freeze(chain)
+
+	return newModel(frozen, smoothing, vocabulary(frozen))
+}
+
+// newModel creates a model with the predefined vocabulary,
+// the vocabulary must be sorted and contain all words of the chain.
+func newModel[entry Ordered](chain *FrozenChain[entry], smoothing Smoothing, words []entry) *Model[entry] {
+	model := &Model[entry]{
+		smoothing:     smoothing,
+		vocabulary:    words,
+		firstWord:     chain.firstWord,
+		wordsCount:    chain.wordsCount,
+		transitions:   make(map[Pair[entry]]int, len(chain.pairs)),
+		outgoing:      map[entry]int{},
+		successors:    map[entry]int{},
+		predecessors:  map[entry]int{},
+		distinctPairs: len(chain.pairs),
+	}
+
+	for i, pair := range chain.pairs {
+		count := chain.counts[i]
+
+		model.transitions[pair] = count
+		model.outgoing[pair.First] += count
+		model.successors[pair.First]++
+		model.predecessors[pair.Second]++
+	}
+
+	return model
+}
+
+// vocabulary returns sorted unique words of the chains.
+func vocabulary[entry Ordered](chains ...*FrozenChain[entry]) []entry {
+	unique := map[entry]struct{}{}
+
+	for _, chain := range chains {
+		if chain.wordsCount > 0 {
+			unique[chain.firstWord] = struct{}{}
+		}
+
+		for _, pair := range chain.pairs {
+			unique[pair.First] = struct{}{}
+			unique[pair.Second] = struct{}{}
+		}
+	}
+
+	words := make([]entry, 0, len(unique))
+	for word := range unique {
+		words = append(words, word)
+	}
+
+	sort.Slice(words, func(i, j int) bool {
+		return words[i] < words[j]
+	})
+
+	return words
+}
+
+// Vocabulary returns sorted known words of the model.
+func (m *Model[entry]) Vocabulary() []entry {
+	return append([]entry(nil), m.vocabulary...)
+}
+
+// Probability returns the smoothed conditional probability of the next word after the previous one.
+func (m *Model[entry]) Probability(prev, next entry) float64 {
+	size := float64(len(m.vocabulary) + 1)
+	count := float64(m.transitions[Pair[entry]{First: prev, Second: next}])
+	total := float64(m.outgoing[prev])
+
+	switch m.smoothing.Method {
+	case KneserNey:
+		continuation := (float64(m.predecessors[next]) + 1) / (float64(m.distinctPairs) + size)
+		if total == 0 {
+			return continuation
+		}
+
+		discount := m.smoothing.Discount
+		weight := discount * float64(m.successors[prev]) / total
+
+		return math.Max(count-discount, 0)/total + weight*continuation
+	default:
+		denominator := total + m.smoothing.K*size
+		if denominator == 0 {
+			return 1 / size
+		}
+
+		return (count + m.smoothing.K) / denominator
+	}
+}
+
+// LogLikelihood returns the natural logarithm of the probability of words transitions.
+// The first word is given, so it doesn't contribute to the result.
+// The result is negative infinity when any transition is impossible under the model.
+func (m *Model[entry]) LogLikelihood(words []entry) float64 {
+	likelihood := 0.0
+
+	for i := 1; i < len(words); i++ {
+		likelihood += math.Log(m.Probability(words[i-1], words[i]))
+	}
+
+	return likelihood
+}
+
+// Perplexity returns the perplexity of words under the model.
+// It is normalized per transition, so texts of different lengths are comparable:
+// the lower the perplexity, the more the words look like the modeled chain.
+// Sequences shorter than two words have no transitions and their perplexity is 1.
+func (m *Model[entry]) Perplexity(words []entry) float64 {
+	if len(words) < 2 {
+		return 1
+	}
+
+	return math.Exp(-m.LogLikelihood(words) / float64(len(words)-1))
+}
+
+// Generate samples a sequence of words of the length from the model.
+// The sequence starts with the first word of the chain and each next word
+// is drawn from the vocabulary according to the transition probabilities.
+// The same random source state produces the same sequence.
+func (m *Model[entry]) Generate(rng *rand.Rand, length int) []entry {
+	if length <= 0 || m.wordsCount == 0 {
+		return []entry{}
+	}
+
+	words := make([]entry, 0, length)
+	words = append(words, m.firstWord)
+
+	weights := make([]float64, len(m.vocabulary))
+
+	for len(words) < length {
+		prev := words[len(words)-1]
+
+		total := 0.0
+		for i, word := range m.vocabulary {
+			weights[i] = m.Probability(prev, word)
+			total += weights[i]
+		}
+
+		point := rng.Float64() * total
+
+		next := len(m.vocabulary) - 1
+		for i, weight := range weights {
+			if point < weight {
+				next = i
+				break
+			}
+			point -= weight
+		}
+
+		words = append(words, m.vocabulary[next])
+	}
+
+	return words
+}
@@ -0,0 +1,114 @@
+package markov
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Probability(t *testing.T) {
+	chain := BuildChain([]string{"a", "b", "a", "c", "a", "b"})
+
+	t.Run("maximum_likelihood", func(t *testing.T) {
+		model := NewModel(chain, Smoothing{Method: AddK})
+
+		assert.InDelta(t, 2./3., model.Probability("a", "b"), delta)
+		assert.InDelta(t, 1./3., model.Probability("a", "c"), delta)
+		assert.InDelta(t, 0, model.Probability("a", "a"), delta)
+		assert.InDelta(t, 1, model.Probability("b", "a"), delta)
+	})
+
+	t.Run("add_one", func(t *testing.T) {
+		model := NewModel(chain, Smoothing{Method: AddK, K: 1})
+
+		// Three known words and the unknown slot.
+		assert.InDelta(t, 3./7., model.Probability("a", "b"), delta)
+		assert.InDelta(t, 1./7., model.Probability("a", "unknown"), delta)
+		assert.InDelta(t, 1./4., model.Probability("unknown", "a"), delta)
+	})
+
+	t.Run("kneser_ney", func(t *testing.T) {
+		model := NewModel(chain, Smoothing{Method: KneserNey, Discount: 0.5})
+
+		// Continuation: a is preceded by 2 words, b and c by 1,
+		// there are 4 distinct pairs and 4 vocabulary slots.
+		continuationA := 3. / 8.
+		continuationB := 2. / 8.
+		weight := 0.5 * 2 / 3
+
+		assert.InDelta(t, 1.5/3+weight*continuationB, model.Probability("a", "b"), delta)
+		assert.InDelta(t, weight*continuationA, model.Probability("a", "a"), delta)
+		assert.InDelta(t, 1./8., model.Probability("unknown", "unknown"), delta)
+	})
+
+	t.Run("distributions_sum_to_one", func(t *testing.T) {
+		smoothings := []Smoothing{
+			{Method: AddK, K: 0.5},
+			{Method: KneserNey, Discount: 0.75},
+		}
+
+		for _, smoothing := range smoothings {
+			model := NewModel(chain, smoothing)
+
+			for _, prev := range []string{"a", "b", "c", "unknown"} {
+				total := model.Probability(prev, "unknown")
+				for _, next := range model.Vocabulary() {
+					total += model.Probability(prev, next)
+				}
+
+				assert.InDelta(t, 1, total, delta)
+			}
+		}
+	})
+}
+
+func TestModel_Perplexity(t *testing.T) {
+	model := NewModel(BuildChain(dummyWords()), Smoothing{Method: KneserNey, Discount: 0.75})
+
+	t.Run("own_text_is_less_perplexing", func(t *testing.T) {
+		own := model.Perplexity(dummyWords())
+		reversed := model.Perplexity([]string{"amet,", "Lorem", "sit", "amet", "dolor", "lorem"})
+
+		assert.Less(t, own, reversed)
+	})
+
+	t.Run("short_sequence", func(t *testing.T) {
+		assert.Equal(t, 1., model.Perplexity([]string{"Lorem"}))
+	})
+
+	t.Run("impossible_transition", func(t *testing.T) {
+		model := NewModel(BuildChain(dummyWords()), Smoothing{Method: AddK})
+
+		assert.True(t, math.IsInf(model.Perplexity([]string{"Lorem", "sit"}), 1))
+	})
+}
+
+func TestModel_Generate(t *testing.T) {
+	model := NewModel(BuildChain(dummyWords()), Smoothing{Method: AddK})
+
+	t.Run("seeded", func(t *testing.T) {
+		first := model.Generate(rand.New(rand.NewSource(1)), 20)
+		second := model.Generate(rand.New(rand.NewSource(1)), 20)
+
+		assert.Len(t, first, 20)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("follows_transitions", func(t *testing.T) {
+		words := model.Generate(rand.New(rand.NewSource(2)), 50)
+		chain := BuildChain(dummyWords())
+
+		assert.Equal(t, "Lorem", words[0])
+		for i := 1; i < len(words); i++ {
+			assert.Positive(t, chain.Frequency(Pair[string]{First: words[i-1], Second: words[i]}))
+		}
+	})
+
+	t.Run("empty_chain", func(t *testing.T) {
+		model := NewModel(BuildChain([]string{}), Smoothing{Method: AddK, K: 1})
+
+		assert.Equal(t, []string{}, model.Generate(rand.New(rand.NewSource(1)), 5))
+	})
+}
@@ -0,0 +1,94 @@
+package markov
+
+import "sort"
+
+// PositionalChain is a markov chain which keeps positions of the pairs in the sequence.
+// It allows to take the order of pairs into account during comparison.
+type PositionalChain[entry comparable] struct {
+	positions  map[Pair[entry]][]int
+	wordsCount int
+	firstWord  entry
+}
+
+// BuildPositionalChain creates an instance of positional markov chain.
+// Each pair of consecutive words is associated with a list of the pair first word positions.
+func BuildPositionalChain[entry comparable](words []entry) *PositionalChain[entry] {
+	chain := &PositionalChain[entry]{
+		positions:  map[Pair[entry]][]int{},
+		wordsCount: len(words),
+	}
+
+	if chain.wordsCount == 0 {
+		return chain
+	}
+
+	chain.firstWord = words[0]
+
+	for i := 1; i < len(words); i++ {
+		pair := Pair[entry]{First: words[i-1], Second: words[i]}
+		chain.positions[pair] = append(chain.positions[pair], i-1)
+	}
+
+	return chain
+}
+
+// Compare matches the chain to the compared one respecting the order of pairs.
+// Occurrences of each common pair are aligned in the order they appear in both chains,
+// then only the longest subset of aligned pairs which appear in the same relative order
+// in both chains is counted as matches (the longest increasing subsequence of aligned positions).
+// For sequences with the same order of pairs the score is equal to the Chain.Compare one,
+// while reordered parts of the sequence lower the score.
+func (c *PositionalChain[entry]) Compare(compared *PositionalChain[entry]) float64 {
+	if c.wordsCount == 0 {
+		return 0
+	}
+
+	totalMatches := 0
+
+	if c.firstWord == compared.firstWord {
+		totalMatches++
+	}
+
+	type alignment struct {
+		left, right int
+	}
+
+	aligned := []alignment{}
+
+	for pair, positions := range c.positions {
+		comparedPositions := compared.positions[pair]
+
+		for i := 0; i < min(len(positions), len(comparedPositions)); i++ {
+			aligned = append(aligned, alignment{left: positions[i], right: comparedPositions[i]})
+		}
+	}
+
+	sort.Slice(aligned, func(i, j int) bool {
+		return aligned[i].left < aligned[j].left
+	})
+
+	rights := make([]int, len(aligned))
+	for i, a := range aligned {
+		rights[i] = a.right
+	}
+
+	totalMatches += longestIncreasingSubsequence(rights)
+
+	return float64(totalMatches) / float64(max(c.wordsCount, compared.wordsCount))
+}
+
+// longestIncreasingSubsequence returns the length of the longest strictly increasing subsequence.
+func longestIncreasingSubsequence(values []int) int {
+	tails := []int{}
+
+	for _, value := range values {
+		i := sort.SearchInts(tails, value)
+		if i == len(tails) {
+			tails = append(tails, value)
+		} else {
+			tails[i] = value
+		}
+	}
+
+	return len(tails)
+}
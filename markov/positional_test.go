@@ -0,0 +1,83 @@
+package markov
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildPositionalChain(t *testing.T) {
+	t.Run("many_words", func(t *testing.T) {
+		expected := &PositionalChain[string]{
+			positions: map[Pair[string]][]int{
+				{First: "Lorem", Second: "ipsum"}: {0, 3},
+				{First: "ipsum", Second: "dolor"}: {1},
+				{First: "dolor", Second: "Lorem"}: {2},
+			},
+			wordsCount: 5,
+			firstWord:  "Lorem",
+		}
+
+		result := BuildPositionalChain([]string{"Lorem", "ipsum", "dolor", "Lorem", "ipsum"})
+
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("zero_words", func(t *testing.T) {
+		expected := &PositionalChain[string]{
+			positions: map[Pair[string]][]int{},
+		}
+
+		assert.Equal(t, expected, BuildPositionalChain([]string{}))
+	})
+}
+
+func TestPositionalChain_Compare(t *testing.T) {
+	t.Run("same_order_equals_chain_compare", func(t *testing.T) {
+		comparing := []string{"lorem", "ipsum", "amet", "sit", "Dolor"}
+		compared := []string{
+			"lorem", "ipsum", "amet", "sit", "Dolor",
+			"lorem", "ipsum", "amet", "sit", "Dolor",
+		}
+
+		expected := BuildChain(comparing).Compare(BuildChain(compared))
+
+		confidence := BuildPositionalChain(comparing).Compare(BuildPositionalChain(compared))
+
+		assert.InDelta(t, expected, confidence, delta)
+	})
+
+	t.Run("reordered_clauses", func(t *testing.T) {
+		original := []string{
+			"a", "b", "c", "d",
+			"e", "f", "g", "h",
+			"i", "j", "k", "l",
+		}
+		reordered := []string{
+			"a", "b", "c", "d",
+			"i", "j", "k", "l",
+			"e", "f", "g", "h",
+		}
+
+		unordered := BuildChain(original).Compare(BuildChain(reordered))
+		ordered := BuildPositionalChain(original).Compare(BuildPositionalChain(reordered))
+
+		// Pairs a-b, b-c, c-d, e-f, f-g, g-h, i-j, j-k, k-l and the first word.
+		assert.InDelta(t, 10./12., unordered, delta)
+		// Pairs e-f, f-g, g-h are out of order.
+		assert.InDelta(t, 7./12., ordered, delta)
+	})
+
+	t.Run("left_empty", func(t *testing.T) {
+		comparing := BuildPositionalChain([]string{})
+		compared := BuildPositionalChain(dummyWords())
+
+		assert.InDelta(t, 0, comparing.Compare(compared), delta)
+	})
+
+	t.Run("self", func(t *testing.T) {
+		chain := BuildPositionalChain(dummyWords())
+
+		assert.Equal(t, 1., chain.Compare(chain))
+	})
+}
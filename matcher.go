@@ -1,6 +1,8 @@
 package compare
 
 import (
+	"math"
+
 	"github.com/radikh/compare/markov"
 )
 
@@ -8,62 +10,146 @@ import (
 type Text struct {
 	Name    string
 	Content string
+	// Required phrases must be present in a matched text,
+	// otherwise the match is demoted.
+	Required []string
+	// Forbidden phrases must be absent in a matched text,
+	// otherwise the match is demoted.
+	Forbidden []string
 }
 
 type chainEntry struct {
 	textName string
-	chain    *markov.Chain[string]
+	chain    *markov.FrozenChain[string]
+	rules    []phraseRule
 }
 
 // TextMatcher is an implementation of matcher that uses
 // markov chains for comparison.
 type TextMatcher struct {
 	chains []chainEntry
+	// frequencies is the number of recorded texts each pair appears in.
+	frequencies map[markov.Pair[string]]int
+	// calibration is used for classification, the default one is used when it's nil.
+	calibration *Calibration
+	// tokenizer splits stored and matched texts in tokens.
+	tokenizer Tokenizer
 }
 
 // NewTextMatcher creates an istance of Markov matcher
 // and preprocesses the texts to be ready for comparison operation.
 func NewTextMatcher(texts ...Text) *TextMatcher {
 	matcher := &TextMatcher{
-		chains: make([]chainEntry, 0, len(texts)),
+		chains:      make([]chainEntry, 0, len(texts)),
+		frequencies: map[markov.Pair[string]]int{},
 	}
 
 	for _, text := range texts {
-		words := Tokenize(text.Content)
+		matcher.FeedText(text)
+	}
+	return matcher
+}
 
-		entry := chainEntry{
-			chain:    markov.BuildChain(words),
-			textName: text.Name,
-		}
-		matcher.chains = append(matcher.chains, entry)
+// NewTextMatcherWithTokenizer creates a matcher which splits stored
+// and matched texts in tokens with the tokenizer instead of Tokenize.
+func NewTextMatcherWithTokenizer(tokenizer Tokenizer, texts ...Text) *TextMatcher {
+	matcher := NewTextMatcher()
+	matcher.tokenizer = tokenizer
+
+	for _, text := range texts {
+		matcher.FeedText(text)
 	}
 	return matcher
 }
 
 // Feed records a text to be compared with other texts.
-// Names may duplicate.
+// Names may duplicate. The chain of the text is frozen right away
+// as it is never changed after being recorded.
 func (mm *TextMatcher) Feed(name, text string) {
-	words := Tokenize(text)
+	mm.FeedText(Text{Name: name, Content: text})
+}
+
+// FeedText records a text with its phrase rules to be compared with other texts.
+// Copyright statements are not taken into account.
+func (mm *TextMatcher) FeedText(text Text) {
+	words := mm.tokenizer.Tokenize(StripCopyrights(text.Content))
 
 	entry := chainEntry{
-		chain:    markov.BuildChain(words),
-		textName: name,
+		chain:    markov.Freeze(markov.BuildChain(words)),
+		textName: text.Name,
+		rules:    buildRules(text, mm.tokenizer),
 	}
 	mm.chains = append(mm.chains, entry)
+
+	if mm.frequencies == nil {
+		mm.frequencies = map[markov.Pair[string]]int{}
+	}
+
+	for _, pair := range entry.chain.Pairs() {
+		mm.frequencies[pair]++
+	}
+}
+
+// Remove deletes all the texts with the name from the matcher.
+// It returns the number of deleted texts.
+func (mm *TextMatcher) Remove(name string) int {
+	kept := mm.chains[:0]
+
+	for _, entry := range mm.chains {
+		if entry.textName != name {
+			kept = append(kept, entry)
+			continue
+		}
+
+		for _, pair := range entry.chain.Pairs() {
+			mm.frequencies[pair]--
+			if mm.frequencies[pair] <= 0 {
+				delete(mm.frequencies, pair)
+			}
+		}
+	}
+
+	removed := len(mm.chains) - len(kept)
+	mm.chains = kept
+
+	return removed
 }
 
 // Match perform comparison of text with texts that were stored on matcher
 // creation step. Result contains list of matches with all stored texts.
+// Copyright statements of the text are not taken into account.
+// Confidence of texts which phrase rules fire is demoted.
 func (mm *TextMatcher) Match(text string) []Match {
-	words := Tokenize(text)
-	comparable := markov.BuildChain(words)
+	return mm.match(text, func(chain, comparable *markov.FrozenChain[string]) float64 {
+		return chain.Compare(comparable)
+	})
+}
+
+// MatchWeighted performs comparison of text with stored texts like Match does,
+// but pairs of words are weighted with their inverse document frequency across
+// the stored texts. So pairs common for many texts like "of the" barely affect
+// the confidence, while distinctive pairs like "affero general" dominate it.
+func (mm *TextMatcher) MatchWeighted(text string) []Match {
+	return mm.match(text, func(chain, comparable *markov.FrozenChain[string]) float64 {
+		return chain.WeightedCompare(comparable, mm.inverseFrequency)
+	})
+}
+
+func (mm *TextMatcher) match(text string, score func(chain, comparable *markov.FrozenChain[string]) float64) []Match {
+	words := mm.tokenizer.Tokenize(StripCopyrights(text))
+	comparable := markov.Freeze(markov.BuildChain(words))
 
 	result := make([]Match, 0, len(mm.chains))
 
 	for _, entry := range mm.chains {
 		match := Match{
 			TextName:   entry.textName,
-			Confidence: entry.chain.Compare(comparable),
+			Confidence: score(entry.chain, comparable),
+			Rules:      firedRules(entry.rules, words),
+		}
+
+		if len(match.Rules) > 0 {
+			match.Confidence *= ruleDemotion
 		}
 
 		result = append(result, match)
@@ -72,6 +158,14 @@ func (mm *TextMatcher) Match(text string) []Match {
 	return result
 }
 
+// inverseFrequency returns smoothed inverse document frequency of the pair,
+// pairs absent in the stored texts get the highest weight.
+func (mm *TextMatcher) inverseFrequency(pair markov.Pair[string]) float64 {
+	documents := float64(len(mm.chains))
+
+	return math.Log((1+documents)/(1+float64(mm.frequencies[pair]))) + 1
+}
+
 // Match desribe the matching output.
 type Match struct {
 	// TextName is the name of the text the match related to
@@ -79,4 +173,7 @@ type Match struct {
 	// Confidence is the percentage of texts similarity
 	// for markov chain matcher is between 0 and 1.
 	Confidence float64
+	// Rules lists phrase rules of the text which fired,
+	// the confidence of such a match is demoted.
+	Rules []Rule
 }
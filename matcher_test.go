@@ -58,62 +58,62 @@ func dummyChains() []chainEntry {
 	return []chainEntry{
 		{
 			textName: "lorem_ipsum",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Lorem ipsum dolor sit amet, consectetur adipiscing elit, 
 					sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. 
 					Ut enim ad minim veniam, quis nostrud exercitation ullamco 
 					laboris nisi ut aliquip ex ea commodo consequat.`,
 				),
-			),
+			)),
 		},
 		{
 			textName: "excepteur_sint",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Excepteur sint occaecat cupidatat non proident, 
 					sunt in culpa qui officia deserunt mollit anim id est laborum.`,
 				),
-			),
+			)),
 		},
 		{
 			textName: "occae_cat",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Excepteur sint occaecat cupidatat non proident, 
 					sunt in culpa qui officia deserunt mollit anim id est laborum.`,
 				),
-			),
+			)),
 		},
 		{
 			textName: "cupidat_non",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Excepteur sint occaecat cupidatat non proident, 
 					sunt in culpa qui officia deserunt mollit anim id est laborum.`,
 				),
-			),
+			)),
 		},
 		{
 			textName: "ut_mauris",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Ut mauris ipsum, viverra quis velit eget, vehicula 
 					sodales nunc. Sed orci felis, placerat quis enim vitae, semper tempus erat. 
 					Integer non enim pharetra, molestie.`,
 				),
-			),
+			)),
 		},
 		{
 			textName: "vivamus_eu",
-			chain: markov.BuildChain(
+			chain: markov.Freeze(markov.BuildChain(
 				Tokenize(
 					`Vivamus eu tempor quam. Nulla vehicula lorem ut dolor 
 					consectetur rhoncus. Ut mauris ipsum, viverra quis velit eget, vehicula 
 					sodales nunc. Sed orci felis, placerat quis enim vitae, semper tempus erat. 
 					Integer non enim pharetra, molestie nulla ut, iaculis turpis.`,
 				),
-			),
+			)),
 		},
 	}
 }
@@ -146,6 +146,22 @@ func TestNewMarkovMatcher(t *testing.T) {
 	assertMarkovMatchers(t, matcher, expected)
 }
 
+func TestNewTextMatcherWithTokenizer(t *testing.T) {
+	texts := []Text{
+		{Name: "naive", Content: "A naïve approach to the problem"},
+		{Name: "other", Content: "Something completely different here"},
+	}
+
+	// The Cyrillic "а" and "о" and the missing diaeresis make the text look the same.
+	spoofed := "\u0430 naive appr\u043each to the problem"
+
+	folded := NewTextMatcherWithTokenizer(Tokenizer{FoldDiacritics: true, FoldConfusables: true}, texts...)
+	assert.Equal(t, 1.0, folded.Match(spoofed)[0].Confidence)
+
+	plain := NewTextMatcher(texts...)
+	assert.Less(t, plain.Match(spoofed)[0].Confidence, 0.5)
+}
+
 func TestMatcher_Match(t *testing.T) {
 	type testcase struct {
 		text     string
@@ -241,6 +257,72 @@ func TestMatcher_Match(t *testing.T) {
 	}
 }
 
+func TestMatcher_Remove(t *testing.T) {
+	t.Run("removes_all_with_name", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+		matcher.Feed("lorem_ipsum", "Lorem ipsum dolor sit amet.")
+
+		removed := matcher.Remove("lorem_ipsum")
+
+		assert.Equal(t, 2, removed)
+		assert.Len(t, matcher.chains, 5)
+
+		for _, entry := range matcher.chains {
+			assert.NotEqual(t, "lorem_ipsum", entry.textName)
+		}
+	})
+
+	t.Run("frequencies_follow_corpus", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+		expected := NewTextMatcher(dummyTexts()...)
+
+		matcher.Feed("extra", "Lorem ipsum dolor sit amet, sunt in culpa.")
+		matcher.Remove("extra")
+
+		assert.Equal(t, expected.frequencies, matcher.frequencies)
+	})
+
+	t.Run("missing_name", func(t *testing.T) {
+		matcher := NewTextMatcher(dummyTexts()...)
+
+		assert.Equal(t, 0, matcher.Remove("missing"))
+		assert.Len(t, matcher.chains, 6)
+	})
+}
+
+func TestMatcher_MatchWeighted(t *testing.T) {
+	matcher := NewTextMatcher(
+		Text{Name: "affero", Content: "This is the license of the Affero General Public License software."},
+		Text{Name: "lesser", Content: "This is the license of the Lesser General Public License software."},
+		Text{Name: "other", Content: "This is the text of the other software."},
+	)
+
+	text := "Affero General Public License"
+
+	weighted := map[string]float64{}
+	for _, match := range matcher.MatchWeighted(text) {
+		weighted[match.TextName] = match.Confidence
+	}
+
+	plain := map[string]float64{}
+	for _, match := range matcher.Match(text) {
+		plain[match.TextName] = match.Confidence
+	}
+
+	assert.Greater(t, weighted["affero"]-weighted["lesser"], plain["affero"]-plain["lesser"])
+	assert.Zero(t, weighted["other"])
+
+	t.Run("weights_update_on_feed", func(t *testing.T) {
+		before := matcher.inverseFrequency(markov.Pair[string]{First: "affero", Second: "general"})
+
+		matcher.Feed("affero_copy", "Affero General Public License")
+
+		after := matcher.inverseFrequency(markov.Pair[string]{First: "affero", Second: "general"})
+
+		assert.Less(t, after, before)
+	})
+}
+
 func ExampleTextMatcher() {
 	matcher := NewTextMatcher()
 
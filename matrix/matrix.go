@@ -0,0 +1,219 @@
+// Package matrix computes all-pairs similarity matrices of text collections for corpus audits.
+// Texts are tokenized and their chains are built once, the scores are computed
+// in parallel and exported to CSV or to JSON ready for heatmap rendering.
+package matrix
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/markov"
+)
+
+// Metric is a similarity measure of chains in range of 0 to 1.
+type Metric struct {
+	Name  string
+	Score func(row, column *markov.FrozenChain[string]) float64
+	// Symmetric metrics are computed once for each pair of texts.
+	Symmetric bool
+}
+
+// Markov returns the metric of CompareTexts, it is symmetric.
+func Markov() Metric {
+	return Metric{
+		Name: "markov",
+		Score: func(row, column *markov.FrozenChain[string]) float64 {
+			return row.Compare(column)
+		},
+		Symmetric: true,
+	}
+}
+
+// Containment returns the share of pairs of the row text present in the column text.
+// It is asymmetric and finds texts which are parts of other texts.
+func Containment() Metric {
+	return Metric{
+		Name: "containment",
+		Score: func(row, column *markov.FrozenChain[string]) float64 {
+			return row.Containment(column)
+		},
+	}
+}
+
+// Options configures the computation.
+type Options struct {
+	// Metric is Markov when it's not set.
+	Metric Metric
+	// Workers is the number of parallel workers, GOMAXPROCS when it's zero.
+	Workers int
+	// Progress is called with the number of computed and total scores after each row.
+	// Calls are serialized, so the function doesn't need to be safe for concurrent use.
+	Progress func(done, total int)
+}
+
+// Matrix is a square matrix of texts similarities.
+type Matrix struct {
+	Metric string
+	Names  []string
+	// Scores is the similarity of the row text to the column text. The diagonal is
+	// computed by the metric too, so it is 1 for texts with pairs and 0 for empty ones.
+	Scores [][]float64
+}
+
+// Compute tokenizes the texts with Tokenize and computes the similarity matrix.
+// It stops early with the context error when the context is done.
+func Compute(ctx context.Context, texts []compare.Text, options Options) (*Matrix, error) {
+	if options.Metric.Score == nil {
+		options.Metric = Markov()
+	}
+
+	if options.Workers <= 0 {
+		options.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	n := len(texts)
+	m := &Matrix{
+		Metric: options.Metric.Name,
+		Names:  make([]string, n),
+		Scores: make([][]float64, n),
+	}
+
+	chains := make([]*markov.FrozenChain[string], n)
+	for i, text := range texts {
+		m.Names[i] = text.Name
+		m.Scores[i] = make([]float64, n)
+		chains[i] = markov.Freeze(markov.BuildChain(compare.Tokenize(text.Content)))
+	}
+
+	total := n * (n - 1)
+	if options.Metric.Symmetric {
+		total /= 2
+	}
+
+	var (
+		progressMu sync.Mutex
+		done       int
+		wg         sync.WaitGroup
+	)
+
+	rows := make(chan int)
+
+	for w := 0; w < options.Workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range rows {
+				computed := m.computeRow(i, chains, options.Metric)
+
+				if options.Progress != nil {
+					progressMu.Lock()
+					done += computed
+					options.Progress(done, total)
+					progressMu.Unlock()
+				}
+			}
+		}()
+	}
+
+	var err error
+
+loop:
+	for i := 0; i < n; i++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		select {
+		case rows <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break loop
+		}
+	}
+
+	close(rows)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// computeRow fills the row of the matrix and returns the number of computed scores
+// off the diagonal. Rows of symmetric metrics fill the cells above the diagonal and mirror them.
+func (m *Matrix) computeRow(i int, chains []*markov.FrozenChain[string], metric Metric) int {
+	computed := 0
+
+	for j := range chains {
+		switch {
+		case i == j:
+			m.Scores[i][i] = metric.Score(chains[i], chains[i])
+			continue
+		case metric.Symmetric && j < i:
+			continue
+		case metric.Symmetric:
+			score := metric.Score(chains[i], chains[j])
+			m.Scores[i][j], m.Scores[j][i] = score, score
+		default:
+			m.Scores[i][j] = metric.Score(chains[i], chains[j])
+		}
+
+		computed++
+	}
+
+	return computed
+}
+
+// WriteCSV writes the matrix with a header row and a column of texts names.
+func (m *Matrix) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(append([]string{""}, m.Names...)); err != nil {
+		return err
+	}
+
+	for i, name := range m.Names {
+		record := make([]string, 0, len(m.Names)+1)
+		record = append(record, name)
+
+		for _, score := range m.Scores[i] {
+			record = append(record, strconv.FormatFloat(score, 'f', 4, 64))
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// heatmap is the JSON form of the matrix, rows and columns share the labels.
+type heatmap struct {
+	Metric string      `json:"metric"`
+	Labels []string    `json:"labels"`
+	Values [][]float64 `json:"values"`
+}
+
+// WriteJSON writes the matrix as a JSON object with the metric name,
+// labels of rows and columns and values as an array of rows,
+// which most heatmap renderers accept as is.
+func (m *Matrix) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(heatmap{
+		Metric: m.Metric,
+		Labels: m.Names,
+		Values: m.Scores,
+	})
+}
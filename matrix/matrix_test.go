@@ -0,0 +1,110 @@
+package matrix
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func texts() []compare.Text {
+	return []compare.Text{
+		{Name: "a", Content: "Lorem ipsum dolor sit amet"},
+		{Name: "b", Content: "Lorem ipsum dolor sit amet consectetur adipiscing elit"},
+		{Name: "c", Content: "Sed do eiusmod tempor"},
+		{Name: "empty", Content: ""},
+	}
+}
+
+func TestCompute(t *testing.T) {
+	t.Run("markov", func(t *testing.T) {
+		progress := []int{}
+
+		m, err := Compute(context.Background(), texts(), Options{
+			Workers: 2,
+			Progress: func(done, total int) {
+				assert.Equal(t, 6, total)
+				progress = append(progress, done)
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "markov", m.Metric)
+		assert.Equal(t, []string{"a", "b", "c", "empty"}, m.Names)
+
+		for i, row := range texts() {
+			for j, column := range texts() {
+				expected := compare.CompareTexts(row.Content, column.Content)
+				assert.InDelta(t, expected, m.Scores[i][j], 1e-9, "%s and %s", row.Name, column.Name)
+			}
+		}
+
+		assert.Len(t, progress, 4)
+		assert.Equal(t, 6, progress[len(progress)-1])
+		assert.Equal(t, 0., m.Scores[3][3])
+	})
+
+	t.Run("containment", func(t *testing.T) {
+		m, err := Compute(context.Background(), texts(), Options{Metric: Containment()})
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]float64{
+			{1, 1, 0, 0},
+			{4. / 7., 1, 0, 0},
+			{0, 0, 1, 0},
+			{0, 0, 0, 0},
+		}, m.Scores)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := Compute(ctx, texts(), Options{Workers: 1})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("parallel_equals_sequential", func(t *testing.T) {
+		many := []compare.Text{}
+		for i := 0; i < 30; i++ {
+			many = append(many, compare.Text{
+				Name:    strings.Repeat("x", i),
+				Content: strings.Repeat("lorem ipsum ", i) + "dolor sit amet",
+			})
+		}
+
+		sequential, err := Compute(context.Background(), many, Options{Workers: 1})
+		require.NoError(t, err)
+
+		parallel, err := Compute(context.Background(), many, Options{Workers: 8})
+		require.NoError(t, err)
+
+		assert.Equal(t, sequential, parallel)
+	})
+}
+
+func TestMatrix_Write(t *testing.T) {
+	m := &Matrix{
+		Metric: "markov",
+		Names:  []string{"a", "b,c"},
+		Scores: [][]float64{{1, 0.5}, {0.5, 1}},
+	}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, m.WriteCSV(&buf))
+	assert.Equal(t, ""+
+		",a,\"b,c\"\n"+
+		"a,1.0000,0.5000\n"+
+		"\"b,c\",0.5000,1.0000\n",
+		buf.String())
+
+	buf.Reset()
+
+	require.NoError(t, m.WriteJSON(&buf))
+	assert.JSONEq(t, `{"metric": "markov", "labels": ["a", "b,c"], "values": [[1, 0.5], [0.5, 1]]}`, buf.String())
+}
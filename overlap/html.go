@@ -0,0 +1,96 @@
+package overlap
+
+import (
+	"html/template"
+	"io"
+)
+
+// segment is a piece of a document text, Passage is the number of the passage
+// highlighted in it starting from 1, or zero when the piece is not shared.
+type segment struct {
+	Text    string
+	Passage int
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.TitleA}} / {{.TitleB}}</title>
+<style>
+body { font-family: sans-serif; margin: 1em; }
+table { width: 100%; border-collapse: collapse; table-layout: fixed; }
+th, td { vertical-align: top; padding: 0.5em; border: 1px solid #ccc; }
+td { font-family: monospace; white-space: pre-wrap; word-wrap: break-word; }
+mark { background: #ffe08a; }
+mark.odd { background: #a8e0ff; }
+</style>
+</head>
+<body>
+<table>
+<tr>
+<th>{{.TitleA}}: {{printf "%.1f" .Report.A.Coverage}}% shared</th>
+<th>{{.TitleB}}: {{printf "%.1f" .Report.B.Coverage}}% shared</th>
+</tr>
+<tr>
+<td>{{template "segments" .SegmentsA}}</td>
+<td>{{template "segments" .SegmentsB}}</td>
+</tr>
+</table>
+<p>{{len .Report.Passages}} shared passages</p>
+</body>
+</html>
+{{define "segments"}}{{range .}}{{if .Passage}}<mark data-passage="{{.Passage}}" title="passage {{.Passage}}"{{if odd .Passage}} class="odd"{{end}}>{{.Text}}</mark>{{else}}{{.Text}}{{end}}{{end}}{{end}}
+`
+
+// WriteHTML renders the documents side by side with shared passages highlighted.
+// The titles name the documents in the page.
+func (r *Report) WriteHTML(w io.Writer, titleA, titleB string) error {
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"odd": func(n int) bool { return n%2 == 1 },
+	}).Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	return tmpl.Execute(w, struct {
+		TitleA, TitleB       string
+		Report               *Report
+		SegmentsA, SegmentsB []segment
+	}{
+		TitleA:    titleA,
+		TitleB:    titleB,
+		Report:    r,
+		SegmentsA: segments(r.A.Text, r.Passages, func(p Passage) Span { return p.A }),
+		SegmentsB: segments(r.B.Text, r.Passages, func(p Passage) Span { return p.B }),
+	})
+}
+
+// segments cuts the text in pieces at the edges of the passages,
+// overlapping passages are highlighted with the earlier one.
+func segments(text string, passages []Passage, side func(Passage) Span) []segment {
+	owners := make([]int, len(text))
+
+	for i, passage := range passages {
+		s := side(passage)
+		for offset := s.Start; offset < s.End; offset++ {
+			if owners[offset] == 0 {
+				owners[offset] = i + 1
+			}
+		}
+	}
+
+	result := []segment{}
+
+	for start := 0; start < len(text); {
+		end := start
+		for end < len(text) && owners[end] == owners[start] {
+			end++
+		}
+
+		result = append(result, segment{Text: text[start:end], Passage: owners[start]})
+		start = end
+	}
+
+	return result
+}
@@ -0,0 +1,148 @@
+// Package overlap reports passages shared by two documents, which is useful
+// to find plagiarism or to see what a modified license text changed.
+//
+// Documents are tokenized with the normalization of Tokenize,
+// so variants of quotes, dashes and letter case don't break passages.
+package overlap
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/radikh/compare"
+)
+
+// DefaultMinTokens is the minimal length of a passage used when Options.MinTokens is zero.
+const DefaultMinTokens = 8
+
+// Options configures the report.
+type Options struct {
+	// MinTokens is the minimal number of tokens of a reported passage.
+	MinTokens int
+}
+
+// Span is a range of a document. Tokens are half-open indexes of its tokens,
+// Start and End are half-open byte offsets in the document text.
+type Span struct {
+	StartToken, EndToken int
+	Start, End           int
+}
+
+// Passage is a sequence of tokens present in both documents.
+type Passage struct {
+	A, B Span
+	// Tokens is the length of the passage.
+	Tokens int
+}
+
+// Document is a compared document with its coverage by the shared passages.
+type Document struct {
+	Text   string
+	Tokens int
+	// Coverage is the percentage of the document tokens in the shared passages.
+	Coverage float64
+}
+
+// Report lists shared passages of two documents.
+type Report struct {
+	A, B Document
+	// Passages are ordered by the position in the document A and then in the document B.
+	// Repeated text may make passages overlap.
+	Passages []Passage
+}
+
+// Compare finds every maximal passage of at least MinTokens tokens shared by the documents.
+func Compare(a, b string, options Options) *Report {
+	minTokens := options.MinTokens
+	if minTokens <= 0 {
+		minTokens = DefaultMinTokens
+	}
+
+	tokensA, tokensB := compare.TokenizeWithOffsets(a), compare.TokenizeWithOffsets(b)
+
+	report := &Report{
+		A:        Document{Text: a, Tokens: len(tokensA)},
+		B:        Document{Text: b, Tokens: len(tokensB)},
+		Passages: []Passage{},
+	}
+
+	// positions index the starts of all minTokens long sequences of B.
+	positions := map[string][]int{}
+	for j := 0; j+minTokens <= len(tokensB); j++ {
+		key := sequenceKey(tokensB[j : j+minTokens])
+		positions[key] = append(positions[key], j)
+	}
+
+	for i := 0; i+minTokens <= len(tokensA); i++ {
+		for _, j := range positions[sequenceKey(tokensA[i:i+minTokens])] {
+			// The passage was found from an earlier start on the same diagonal.
+			if i > 0 && j > 0 && tokensA[i-1].Text == tokensB[j-1].Text {
+				continue
+			}
+
+			length := minTokens
+			for i+length < len(tokensA) && j+length < len(tokensB) && tokensA[i+length].Text == tokensB[j+length].Text {
+				length++
+			}
+
+			report.Passages = append(report.Passages, Passage{
+				A:      span(tokensA, i, i+length),
+				B:      span(tokensB, j, j+length),
+				Tokens: length,
+			})
+		}
+	}
+
+	report.A.Coverage = coverage(report.Passages, len(tokensA), func(p Passage) Span { return p.A })
+	report.B.Coverage = coverage(report.Passages, len(tokensB), func(p Passage) Span { return p.B })
+
+	return report
+}
+
+func sequenceKey(tokens []compare.Token) string {
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+
+	return strings.Join(words, "\x00")
+}
+
+func span(tokens []compare.Token, start, end int) Span {
+	return Span{
+		StartToken: start,
+		EndToken:   end,
+		Start:      tokens[start].Start,
+		End:        tokens[end-1].End,
+	}
+}
+
+// coverage returns the percentage of tokens covered by the passages in one of the documents.
+func coverage(passages []Passage, total int, side func(Passage) Span) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	spans := make([]Span, len(passages))
+	for i, passage := range passages {
+		spans[i] = side(passage)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].StartToken < spans[j].StartToken
+	})
+
+	covered, end := 0, 0
+	for _, s := range spans {
+		if s.StartToken > end {
+			end = s.StartToken
+		}
+
+		if s.EndToken > end {
+			covered += s.EndToken - end
+			end = s.EndToken
+		}
+	}
+
+	return 100 * float64(covered) / float64(total)
+}
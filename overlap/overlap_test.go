@@ -0,0 +1,78 @@
+package overlap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	a := `The quick brown fox jumps over the lazy dog. Nothing else here.`
+	b := `Intro: THE QUICK BROWN FOX JUMPS — over the lazy dog. The quick brown fox jumps high.`
+
+	t.Run("passages", func(t *testing.T) {
+		report := Compare(a, b, Options{MinTokens: 4})
+
+		require.Len(t, report.Passages, 3)
+
+		first := report.Passages[0]
+		assert.Equal(t, 5, first.Tokens)
+		assert.Equal(t, "The quick brown fox jumps", a[first.A.Start:first.A.End])
+		assert.Equal(t, "THE QUICK BROWN FOX JUMPS", b[first.B.Start:first.B.End])
+
+		second := report.Passages[1]
+		assert.Equal(t, 5, second.Tokens)
+		assert.Equal(t, Span{StartToken: 0, EndToken: 5, Start: 0, End: 25}, second.A)
+		assert.Equal(t, "The quick brown fox jumps", b[second.B.Start:second.B.End])
+
+		third := report.Passages[2]
+		assert.Equal(t, 4, third.Tokens)
+		assert.Equal(t, "over the lazy dog.", a[third.A.Start:third.A.End])
+		assert.Equal(t, "over the lazy dog.", b[third.B.Start:third.B.End])
+
+		assert.Equal(t, 12, report.A.Tokens)
+		assert.Equal(t, 17, report.B.Tokens)
+		assert.InDelta(t, 100*9./12., report.A.Coverage, 1e-9)
+		assert.InDelta(t, 100*14./17., report.B.Coverage, 1e-9)
+	})
+
+	t.Run("dash_does_not_break_passage", func(t *testing.T) {
+		report := Compare("one two — three four five", "one two – three four five", Options{MinTokens: 3})
+
+		require.Len(t, report.Passages, 1)
+		assert.Equal(t, 6, report.Passages[0].Tokens)
+		assert.Equal(t, 100., report.A.Coverage)
+	})
+
+	t.Run("too_short", func(t *testing.T) {
+		report := Compare(a, b, Options{})
+
+		assert.Empty(t, report.Passages)
+		assert.Equal(t, 0., report.A.Coverage)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		report := Compare("", b, Options{MinTokens: 1})
+
+		assert.Empty(t, report.Passages)
+		assert.Equal(t, 0., report.A.Coverage)
+		assert.Equal(t, 0., report.B.Coverage)
+	})
+}
+
+func TestReport_WriteHTML(t *testing.T) {
+	report := Compare("a <b> c d e", "x <B> C D E y", Options{MinTokens: 3})
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteHTML(&buf, "left.txt", "right & co"))
+
+	html := buf.String()
+	assert.Contains(t, html, "<title>left.txt / right &amp; co</title>")
+	assert.Contains(t, html, `<td>a <mark data-passage="1" title="passage 1" class="odd">&lt;b&gt; c d e</mark></td>`)
+	assert.Contains(t, html, `<td>x <mark data-passage="1" title="passage 1" class="odd">&lt;B&gt; C D E</mark> y</td>`)
+	assert.Contains(t, html, "80.0% shared")
+	assert.True(t, strings.HasSuffix(strings.TrimSpace(html), "</html>"))
+}
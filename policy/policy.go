@@ -0,0 +1,303 @@
+// Package policy decides whether detected licenses are acceptable.
+// A policy lists allowed, denied and review-required license identifiers
+// with per-module exceptions and evaluates SPDX license expressions against them,
+// so a scan can fail a build.
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/radikh/compare/spdx"
+	"gopkg.in/yaml.v3"
+)
+
+// Verdict is a decision on a license, verdicts are ordered by severity.
+type Verdict int
+
+const (
+	// Allowed licenses may be used.
+	Allowed Verdict = iota
+	// Review licenses must be reviewed before use.
+	Review
+	// Denied licenses must not be used.
+	Denied
+)
+
+// String returns the name of the verdict as it's written in policies.
+func (v Verdict) String() string {
+	switch v {
+	case Allowed:
+		return "allow"
+	case Review:
+		return "review"
+	default:
+		return "deny"
+	}
+}
+
+// UnmarshalText parses the verdict name.
+func (v *Verdict) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "allow":
+		*v = Allowed
+	case "review":
+		*v = Review
+	case "deny":
+		*v = Denied
+	default:
+		return fmt.Errorf("unknown verdict %q", text)
+	}
+
+	return nil
+}
+
+// Exception allows licenses for modules matching a path glob.
+type Exception struct {
+	Module   string   `yaml:"module"`
+	Licenses []string `yaml:"licenses"`
+	Reason   string   `yaml:"reason"`
+}
+
+// Policy is a set of license rules.
+// License identifiers are compared in the canonical form, so deprecated identifiers
+// like "GPL-2.0" and "GPL-2.0+" match rules for "GPL-2.0-only" and "GPL-2.0-or-later".
+// A rule for "GPL-2.0-only WITH Classpath-exception-2.0" takes precedence over
+// a rule for "GPL-2.0-only".
+type Policy struct {
+	Allow      []string    `yaml:"allow"`
+	Deny       []string    `yaml:"deny"`
+	Review     []string    `yaml:"review"`
+	Exceptions []Exception `yaml:"exceptions"`
+	// Default is the verdict for licenses which are not listed, Review when omitted.
+	Default *Verdict `yaml:"default"`
+}
+
+// Load reads a YAML or JSON policy file.
+func Load(filename string) (*Policy, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(content)
+}
+
+// Parse parses a YAML or JSON policy, unknown fields are rejected to catch typos.
+// Listed licenses are converted to the canonical form, malformed ones are rejected.
+func Parse(content []byte) (*Policy, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	policy := &Policy{}
+	if err := decoder.Decode(policy); err != nil {
+		return nil, fmt.Errorf("parse policy: %w", err)
+	}
+
+	lists := []*[]string{&policy.Allow, &policy.Deny, &policy.Review}
+
+	for i, exception := range policy.Exceptions {
+		if _, err := path.Match(exception.Module, ""); err != nil {
+			return nil, fmt.Errorf("parse policy: exception module %q: %w", exception.Module, err)
+		}
+
+		lists = append(lists, &policy.Exceptions[i].Licenses)
+	}
+
+	for _, list := range lists {
+		for i, license := range *list {
+			canonical, err := spdx.Canonical(license)
+			if err != nil {
+				return nil, fmt.Errorf("parse policy: license %q: %w", license, err)
+			}
+
+			(*list)[i] = canonical
+		}
+	}
+
+	return policy, nil
+}
+
+// Violation is a license of an expression which is not allowed.
+type Violation struct {
+	Module  string
+	License string
+	Verdict Verdict
+}
+
+// Result is a verdict on the license expression of a module.
+type Result struct {
+	Module     string
+	Expression string
+	Verdict    Verdict
+	// Violations are the licenses which caused the verdict, empty for allowed expressions.
+	Violations []Violation
+}
+
+// Evaluate decides on the license expression of the module.
+// All licenses of an AND expression must be acceptable, the most acceptable
+// option of an OR expression is chosen. Violations have normalized identifiers.
+func (p *Policy) Evaluate(module string, expression spdx.Expression) Result {
+	verdict, violations := p.evaluate(module, spdx.Normalize(expression))
+
+	return Result{
+		Module:     module,
+		Expression: expression.String(),
+		Verdict:    verdict,
+		Violations: violations,
+	}
+}
+
+func (p *Policy) evaluate(module string, expression spdx.Expression) (Verdict, []Violation) {
+	switch e := expression.(type) {
+	case spdx.And:
+		verdict, violations := Allowed, []Violation(nil)
+
+		for _, term := range e.Terms {
+			termVerdict, termViolations := p.evaluate(module, term)
+			if termVerdict > verdict {
+				verdict = termVerdict
+			}
+			violations = append(violations, termViolations...)
+		}
+
+		return verdict, violations
+	case spdx.Or:
+		verdict, violations := Denied, []Violation(nil)
+
+		for i, term := range e.Terms {
+			termVerdict, termViolations := p.evaluate(module, term)
+			if i == 0 || termVerdict < verdict {
+				verdict, violations = termVerdict, termViolations
+			}
+		}
+
+		return verdict, violations
+	default:
+		verdict := p.licenseVerdict(module, expression)
+		if verdict == Allowed {
+			return verdict, nil
+		}
+
+		return verdict, []Violation{{Module: module, License: expression.String(), Verdict: verdict}}
+	}
+}
+
+// licenseVerdict decides on a single license or a license with an exception.
+func (p *Policy) licenseVerdict(module string, expression spdx.Expression) Verdict {
+	ids := []string{expression.String()}
+	if with, ok := expression.(spdx.With); ok {
+		ids = append(ids, with.License.String())
+	}
+
+	for _, id := range ids {
+		if p.excepted(module, id) {
+			return Allowed
+		}
+
+		switch {
+		case contains(p.Deny, id):
+			return Denied
+		case contains(p.Review, id):
+			return Review
+		case contains(p.Allow, id):
+			return Allowed
+		}
+	}
+
+	if p.Default != nil {
+		return *p.Default
+	}
+
+	return Review
+}
+
+func (p *Policy) excepted(module, id string) bool {
+	for _, exception := range p.Exceptions {
+		if matched, _ := path.Match(exception.Module, module); matched && contains(exception.Licenses, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether the normalized identifier is listed.
+// Lists of policies created without Parse may have identifiers in any form.
+func contains(ids []string, id string) bool {
+	for _, listed := range ids {
+		if canonical, err := spdx.Canonical(listed); err == nil {
+			listed = canonical
+		}
+
+		if strings.EqualFold(listed, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report is a set of results of a build.
+type Report struct {
+	Results []Result
+}
+
+// Add evaluates the expression of the module and records the result.
+// Malformed expressions are denied.
+func (r *Report) Add(p *Policy, module, expression string) Result {
+	parsed, err := spdx.Parse(expression)
+
+	result := Result{Module: module, Expression: expression}
+	if err != nil {
+		result.Verdict = Denied
+		result.Violations = []Violation{{Module: module, License: expression, Verdict: Denied}}
+	} else {
+		result = p.Evaluate(module, parsed)
+	}
+
+	r.Results = append(r.Results, result)
+
+	return result
+}
+
+// Verdict returns the most severe verdict of the results.
+func (r *Report) Verdict() Verdict {
+	verdict := Allowed
+
+	for _, result := range r.Results {
+		if result.Verdict > verdict {
+			verdict = result.Verdict
+		}
+	}
+
+	return verdict
+}
+
+// Violations returns violations of all the results.
+func (r *Report) Violations() []Violation {
+	violations := []Violation{}
+
+	for _, result := range r.Results {
+		violations = append(violations, result.Violations...)
+	}
+
+	return violations
+}
+
+// ExitCode returns the process exit code for CI:
+// 0 when everything is allowed, 1 when a license is denied
+// and 2 when licenses require review but none is denied.
+func (r *Report) ExitCode() int {
+	switch r.Verdict() {
+	case Denied:
+		return 1
+	case Review:
+		return 2
+	default:
+		return 0
+	}
+}
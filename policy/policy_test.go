@@ -0,0 +1,206 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/radikh/compare/spdx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPolicy(t *testing.T) *Policy {
+	policy, err := Load("testdata/policy.yaml")
+	require.NoError(t, err)
+
+	return policy
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("yaml", func(t *testing.T) {
+		policy := loadPolicy(t)
+
+		assert.Equal(t, []string{"GPL-2.0-only", "AGPL-3.0-only"}, policy.Deny)
+		assert.Equal(t, []Exception{{
+			Module:   "github.com/example/*",
+			Licenses: []string{"AGPL-3.0-only"},
+			Reason:   "internal modules",
+		}}, policy.Exceptions)
+		require.NotNil(t, policy.Default)
+		assert.Equal(t, Denied, *policy.Default)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		policy, err := Load("testdata/policy.json")
+		require.NoError(t, err)
+
+		assert.Equal(t, &Policy{Allow: []string{"MIT"}, Review: []string{"MPL-2.0"}}, policy)
+	})
+
+	t.Run("unknown_field", func(t *testing.T) {
+		_, err := Parse([]byte("alow: [MIT]\n"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown_verdict", func(t *testing.T) {
+		_, err := Parse([]byte("default: maybe\n"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("canonical_lists", func(t *testing.T) {
+		policy, err := Parse([]byte("allow: [mit, 'gpl-2.0 with classpath-exception-2.0']\ndeny: [GPL-2.0+]\n"))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"MIT", "GPL-2.0-only WITH Classpath-exception-2.0"}, policy.Allow)
+		assert.Equal(t, []string{"GPL-2.0-or-later"}, policy.Deny)
+	})
+
+	t.Run("malformed_license", func(t *testing.T) {
+		_, err := Parse([]byte("deny: [MIT OR]\n"))
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		_, err := Load("testdata/missing.yaml")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	policy := loadPolicy(t)
+
+	testcases := map[string]struct {
+		module     string
+		expression string
+		verdict    Verdict
+		violations []Violation
+	}{
+		"allowed": {
+			module:     "a",
+			expression: "mit",
+			verdict:    Allowed,
+		},
+		"denied": {
+			module:     "a",
+			expression: "GPL-2.0-only",
+			verdict:    Denied,
+			violations: []Violation{{Module: "a", License: "GPL-2.0-only", Verdict: Denied}},
+		},
+		"with_exception_allowed": {
+			module:     "a",
+			expression: "GPL-2.0-only WITH Classpath-exception-2.0",
+			verdict:    Allowed,
+		},
+		"or_picks_best": {
+			module:     "a",
+			expression: "GPL-2.0-only OR MPL-2.0 OR MIT",
+			verdict:    Allowed,
+		},
+		"or_reports_best_option": {
+			module:     "a",
+			expression: "GPL-2.0-only OR MPL-2.0",
+			verdict:    Review,
+			violations: []Violation{{Module: "a", License: "MPL-2.0", Verdict: Review}},
+		},
+		"and_takes_worst": {
+			module:     "a",
+			expression: "MIT AND (MPL-2.0 OR GPL-2.0-only) AND AGPL-3.0-only",
+			verdict:    Denied,
+			violations: []Violation{
+				{Module: "a", License: "MPL-2.0", Verdict: Review},
+				{Module: "a", License: "AGPL-3.0-only", Verdict: Denied},
+			},
+		},
+		"deprecated_id_denied": {
+			module:     "a",
+			expression: "GPL-2.0",
+			verdict:    Denied,
+			violations: []Violation{{Module: "a", License: "GPL-2.0-only", Verdict: Denied}},
+		},
+		"module_exception": {
+			module:     "github.com/example/tool",
+			expression: "AGPL-3.0-only",
+			verdict:    Allowed,
+		},
+		"default": {
+			module:     "a",
+			expression: "LicenseRef-unknown",
+			verdict:    Denied,
+			violations: []Violation{{Module: "a", License: "LicenseRef-unknown", Verdict: Denied}},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			expression, err := spdx.Parse(tc.expression)
+			require.NoError(t, err)
+
+			result := policy.Evaluate(tc.module, expression)
+
+			assert.Equal(t, tc.verdict, result.Verdict)
+			assert.Equal(t, tc.violations, result.Violations)
+		})
+	}
+}
+
+func TestPolicy_Evaluate_DeprecatedForms(t *testing.T) {
+	allow := Allowed
+	policy := &Policy{Deny: []string{"GPL-2.0-only", "GPL-2.0-or-later"}, Default: &allow}
+
+	expected := map[string]string{
+		"GPL-2.0":          "GPL-2.0-only",
+		"GPL-2.0+":         "GPL-2.0-or-later",
+		"GPL-2.0-only+":    "GPL-2.0-or-later",
+		"gpl-2.0-or-later": "GPL-2.0-or-later",
+	}
+
+	for expression, license := range expected {
+		report := &Report{}
+		result := report.Add(policy, "a", expression)
+
+		assert.Equal(t, Denied, result.Verdict, expression)
+		assert.Equal(t, []Violation{{Module: "a", License: license, Verdict: Denied}}, result.Violations, expression)
+	}
+
+	literal := &Policy{Deny: []string{"GPL-2.0"}, Default: &allow}
+	assert.Equal(t, Denied, (&Report{}).Add(literal, "a", "GPL-2.0-only").Verdict)
+}
+
+func TestReport(t *testing.T) {
+	policy := loadPolicy(t)
+
+	t.Run("allowed", func(t *testing.T) {
+		report := &Report{}
+		report.Add(policy, "a", "MIT")
+		report.Add(policy, "b", "ISC OR GPL-2.0-only")
+
+		assert.Equal(t, Allowed, report.Verdict())
+		assert.Empty(t, report.Violations())
+		assert.Equal(t, 0, report.ExitCode())
+	})
+
+	t.Run("review", func(t *testing.T) {
+		report := &Report{}
+		report.Add(policy, "a", "MIT")
+		report.Add(policy, "b", "MPL-2.0")
+
+		assert.Equal(t, Review, report.Verdict())
+		assert.Equal(t, 2, report.ExitCode())
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		report := &Report{}
+		report.Add(policy, "a", "MPL-2.0")
+		result := report.Add(policy, "b", "MIT OR")
+
+		assert.Equal(t, Denied, result.Verdict)
+		assert.Equal(t, []Violation{
+			{Module: "a", License: "MPL-2.0", Verdict: Review},
+			{Module: "b", License: "MIT OR", Verdict: Denied},
+		}, report.Violations())
+		assert.Equal(t, 1, report.ExitCode())
+	})
+}
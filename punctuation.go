@@ -0,0 +1,73 @@
+package compare
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Punctuation defines how punctuation at the edges of words is tokenized.
+// Punctuation inside words, like in "don't", "3.14" or URLs, is always kept.
+type Punctuation int
+
+const (
+	// PunctuationKeep keeps punctuation attached to words, so "amet," and "amet" differ.
+	PunctuationKeep Punctuation = iota
+	// PunctuationSplit makes each punctuation character at the edges of words a separate token.
+	PunctuationSplit
+	// PunctuationDrop removes punctuation at the edges of words,
+	// words which consist of punctuation only are dropped.
+	PunctuationDrop
+)
+
+// apply splits the word in tokens by the mode.
+func (p Punctuation) apply(word string) []string {
+	if p == PunctuationKeep {
+		return []string{word}
+	}
+
+	start := 0
+	for start < len(word) {
+		r, size := utf8.DecodeRuneInString(word[start:])
+		if !isPunctuation(r) {
+			break
+		}
+		start += size
+	}
+
+	end := len(word)
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(word[:end])
+		if !isPunctuation(r) {
+			break
+		}
+		end -= size
+	}
+
+	if p == PunctuationDrop {
+		if start == end {
+			return nil
+		}
+
+		return []string{word[start:end]}
+	}
+
+	tokens := []string{}
+	for _, r := range word[:start] {
+		tokens = append(tokens, string(r))
+	}
+
+	if start < end {
+		tokens = append(tokens, word[start:end])
+	}
+
+	for _, r := range word[end:] {
+		tokens = append(tokens, string(r))
+	}
+
+	return tokens
+}
+
+// isPunctuation reports whether the character is a punctuation mark or a symbol, like "," or "§".
+func isPunctuation(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
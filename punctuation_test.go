@@ -0,0 +1,44 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizer_Punctuation(t *testing.T) {
+	const input = `Lorem ipsum, "dolor" sit amet (c) 3.14 don't - http://example.com.`
+
+	testcases := map[Punctuation][]string{
+		PunctuationKeep: {"lorem", "ipsum,", "'dolor'", "sit", "amet", "(c)", "3.14", "don't", "-", "http://example.com."},
+		PunctuationSplit: {
+			"lorem", "ipsum", ",", "'", "dolor", "'", "sit", "amet", "(", "c", ")",
+			"3.14", "don't", "-", "http://example.com", ".",
+		},
+		PunctuationDrop: {"lorem", "ipsum", "dolor", "sit", "amet", "c", "3.14", "don't", "http://example.com"},
+	}
+
+	for mode, expected := range testcases {
+		assert.Equal(t, expected, Tokenizer{Punctuation: mode}.Tokenize(input), mode)
+	}
+
+	t.Run("offsets", func(t *testing.T) {
+		tokens := Tokenizer{Punctuation: PunctuationSplit}.TokenizeWithOffsets("amet, (c)")
+
+		assert.Equal(t, []Token{
+			{Text: "amet", Start: 0, End: 5},
+			{Text: ",", Start: 0, End: 5},
+			{Text: "(", Start: 6, End: 9},
+			{Text: "c", Start: 6, End: 9},
+			{Text: ")", Start: 6, End: 9},
+		}, tokens)
+	})
+
+	t.Run("license_matching", func(t *testing.T) {
+		license := Text{Name: "license", Content: "Permission is granted, free of charge, to any person."}
+		modified := "Permission is granted free of charge to any person"
+
+		assert.Less(t, NewTextMatcher(license).Match(modified)[0].Confidence, 0.9)
+		assert.Equal(t, 1.0, NewTextMatcherWithTokenizer(LicenseTokenizer(), license).Match(modified)[0].Confidence)
+	})
+}
@@ -0,0 +1,119 @@
+// Package report writes scan findings in machine-readable formats:
+// JSON and CSV with a stable schema for dashboards and SARIF 2.1.0 for code scanning UIs.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/radikh/compare/scan"
+)
+
+// SchemaVersion is the version of the JSON and CSV reports schema.
+// It is increased on any incompatible change of the schema.
+const SchemaVersion = 1
+
+// Document is the root of the JSON report.
+type Document struct {
+	SchemaVersion int       `json:"schema_version"`
+	Findings      []Finding `json:"findings"`
+}
+
+// Finding is a scan finding in the JSON report.
+type Finding struct {
+	Path      string  `json:"path"`
+	Kind      string  `json:"kind"`
+	StartLine int     `json:"start_line"`
+	EndLine   int     `json:"end_line"`
+	Decision  string  `json:"decision"`
+	Matches   []Match `json:"matches"`
+}
+
+// Match is a match of a finding in the JSON report.
+type Match struct {
+	License    string  `json:"license"`
+	Confidence float64 `json:"confidence"`
+}
+
+// NewDocument converts findings to the JSON report document.
+func NewDocument(findings []scan.Finding) Document {
+	document := Document{
+		SchemaVersion: SchemaVersion,
+		Findings:      make([]Finding, 0, len(findings)),
+	}
+
+	for _, finding := range findings {
+		converted := Finding{
+			Path:      finding.Path,
+			Kind:      string(finding.Kind),
+			StartLine: finding.Region.StartLine,
+			EndLine:   finding.Region.EndLine,
+			Decision:  finding.Decision.String(),
+			Matches:   make([]Match, 0, len(finding.Matches)),
+		}
+
+		for _, match := range finding.Matches {
+			converted.Matches = append(converted.Matches, Match{
+				License:    match.TextName,
+				Confidence: match.Confidence,
+			})
+		}
+
+		document.Findings = append(document.Findings, converted)
+	}
+
+	return document
+}
+
+// WriteJSON writes findings as an indented JSON document.
+func WriteJSON(w io.Writer, findings []scan.Finding) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(NewDocument(findings))
+}
+
+// csvHeader returns the columns of the CSV report.
+func csvHeader() []string {
+	return []string{"path", "kind", "start_line", "end_line", "decision", "license", "confidence"}
+}
+
+// WriteCSV writes findings as CSV with a header row, one row per finding.
+// The decision is made on the best match, so it is the only match written,
+// findings without matches have empty license and confidence.
+// The JSON report lists all the matches.
+func WriteCSV(w io.Writer, findings []scan.Finding) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader()); err != nil {
+		return err
+	}
+
+	for _, finding := range NewDocument(findings).Findings {
+		license, confidence := "", ""
+		if len(finding.Matches) > 0 {
+			license = finding.Matches[0].License
+			confidence = strconv.FormatFloat(finding.Matches[0].Confidence, 'f', 4, 64)
+		}
+
+		row := []string{
+			finding.Path,
+			finding.Kind,
+			strconv.Itoa(finding.StartLine),
+			strconv.Itoa(finding.EndLine),
+			finding.Decision,
+			license,
+			confidence,
+		}
+
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
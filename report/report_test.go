@@ -0,0 +1,84 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/scan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func dummyFindings() []scan.Finding {
+	return []scan.Finding{
+		{
+			Path:   "LICENSE",
+			Kind:   scan.KindLicenseFile,
+			Region: scan.Region{StartLine: 1, EndLine: 21},
+			Matches: []compare.Match{
+				{TextName: "MIT", Confidence: 0.98},
+				{TextName: "ISC", Confidence: 0.5},
+			},
+			Decision: compare.DecisionNearExact,
+		},
+		{
+			Path:     "third_party/LICENSE",
+			Kind:     scan.KindLicenseFile,
+			Region:   scan.Region{StartLine: 1, EndLine: 1},
+			Matches:  []compare.Match{},
+			Decision: compare.DecisionNone,
+		},
+		{
+			Path:     "lib/gpl.c",
+			Kind:     scan.KindSourceHeader,
+			Region:   scan.Region{StartLine: 2, EndLine: 14},
+			Matches:  []compare.Match{{TextName: "GPL-2.0-only", Confidence: 1}},
+			Decision: compare.DecisionExact,
+		},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteJSON(&buf, dummyFindings()[:2]))
+
+	assert.JSONEq(t, `{
+		"schema_version": 1,
+		"findings": [
+			{
+				"path": "LICENSE",
+				"kind": "license-file",
+				"start_line": 1,
+				"end_line": 21,
+				"decision": "near-exact",
+				"matches": [
+					{"license": "MIT", "confidence": 0.98},
+					{"license": "ISC", "confidence": 0.5}
+				]
+			},
+			{
+				"path": "third_party/LICENSE",
+				"kind": "license-file",
+				"start_line": 1,
+				"end_line": 1,
+				"decision": "none",
+				"matches": []
+			}
+		]
+	}`, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteCSV(&buf, dummyFindings()))
+
+	assert.Equal(t, ""+
+		"path,kind,start_line,end_line,decision,license,confidence\n"+
+		"LICENSE,license-file,1,21,near-exact,MIT,0.9800\n"+
+		"third_party/LICENSE,license-file,1,1,none,,\n"+
+		"lib/gpl.c,source-header,2,14,exact,GPL-2.0-only,1.0000\n",
+		buf.String())
+}
@@ -0,0 +1,159 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/radikh/compare/scan"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFOptions configures the SARIF report.
+type SARIFOptions struct {
+	ToolName    string
+	ToolVersion string
+	// Disallowed reports whether a license must be reported as a violation.
+	Disallowed func(license string) bool
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string          `json:"ruleId"`
+	Level      string          `json:"level"`
+	Message    sarifMessage    `json:"message"`
+	Locations  []sarifLocation `json:"locations"`
+	Properties sarifProperties `json:"properties"`
+}
+
+type sarifProperties struct {
+	Confidence float64 `json:"confidence"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// ruleID returns the SARIF rule identifier of a disallowed license.
+func ruleID(license string) string {
+	return "disallowed-license/" + license
+}
+
+// WriteSARIF writes findings with disallowed licenses as SARIF 2.1.0 log results.
+// Only the best match of a finding is the concluded license, so it is the only one
+// checked. A disallowed license is a result with an error level located at the finding
+// region, or with a warning level when the finding is ambiguous. Other findings are omitted.
+func WriteSARIF(w io.Writer, findings []scan.Finding, options SARIFOptions) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:    options.ToolName,
+				Version: options.ToolVersion,
+				Rules:   []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]struct{}{}
+
+	for _, finding := range findings {
+		match, ok := finding.Best()
+		if !ok || options.Disallowed == nil || !options.Disallowed(match.TextName) {
+			continue
+		}
+
+		id := ruleID(match.TextName)
+		if _, ok := rules[id]; !ok {
+			rules[id] = struct{}{}
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               id,
+				ShortDescription: sarifMessage{Text: fmt.Sprintf("License %s is not allowed", match.TextName)},
+			})
+		}
+
+		level, message := "error", fmt.Sprintf("Disallowed license %s found with confidence %.2f", match.TextName, match.Confidence)
+		if finding.Ambiguous {
+			level, message = "warning", fmt.Sprintf(
+				"Disallowed license %s possibly found with confidence %.2f, other licenses match as well",
+				match.TextName, match.Confidence)
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  id,
+			Level:   level,
+			Message: sarifMessage{Text: message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: finding.Path},
+					Region: sarifRegion{
+						StartLine: finding.Region.StartLine,
+						EndLine:   finding.Region.EndLine,
+					},
+				},
+			}},
+			Properties: sarifProperties{Confidence: match.Confidence},
+		})
+	}
+
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,96 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	t.Run("disallowed", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := WriteSARIF(&buf, dummyFindings(), SARIFOptions{
+			ToolName:    "licensescan",
+			ToolVersion: "1.0.0",
+			Disallowed: func(license string) bool {
+				return license == "GPL-2.0-only"
+			},
+		})
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{
+			"version": "2.1.0",
+			"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+			"runs": [{
+				"tool": {
+					"driver": {
+						"name": "licensescan",
+						"version": "1.0.0",
+						"rules": [{
+							"id": "disallowed-license/GPL-2.0-only",
+							"shortDescription": {"text": "License GPL-2.0-only is not allowed"}
+						}]
+					}
+				},
+				"results": [{
+					"ruleId": "disallowed-license/GPL-2.0-only",
+					"level": "error",
+					"message": {"text": "Disallowed license GPL-2.0-only found with confidence 1.00"},
+					"locations": [{
+						"physicalLocation": {
+							"artifactLocation": {"uri": "lib/gpl.c"},
+							"region": {"startLine": 2, "endLine": 14}
+						}
+					}],
+					"properties": {"confidence": 1}
+				}]
+			}]
+		}`, buf.String())
+	})
+
+	t.Run("runner_up_is_not_reported", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := WriteSARIF(&buf, dummyFindings(), SARIFOptions{
+			ToolName:   "licensescan",
+			Disallowed: func(license string) bool { return license == "ISC" },
+		})
+		require.NoError(t, err)
+
+		assert.NotContains(t, buf.String(), "ISC")
+	})
+
+	t.Run("ambiguous_is_warning", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		findings := dummyFindings()
+		findings[2].Ambiguous = true
+
+		err := WriteSARIF(&buf, findings, SARIFOptions{
+			ToolName:   "licensescan",
+			Disallowed: func(license string) bool { return license == "GPL-2.0-only" },
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, buf.String(), `"level": "warning"`)
+		assert.NotContains(t, buf.String(), `"level": "error"`)
+	})
+
+	t.Run("nothing_disallowed", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		require.NoError(t, WriteSARIF(&buf, dummyFindings(), SARIFOptions{ToolName: "licensescan"}))
+
+		assert.JSONEq(t, `{
+			"version": "2.1.0",
+			"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+			"runs": [{
+				"tool": {"driver": {"name": "licensescan", "rules": []}},
+				"results": []
+			}]
+		}`, buf.String())
+	})
+}
@@ -0,0 +1,91 @@
+package compare
+
+// RuleKind defines how a phrase rule is checked.
+type RuleKind int
+
+const (
+	// RuleRequired fires when the phrase is absent in the matched text.
+	RuleRequired RuleKind = iota
+	// RuleForbidden fires when the phrase is present in the matched text.
+	RuleForbidden
+)
+
+// ruleDemotion is a multiplier of the confidence of a match which rules fired.
+const ruleDemotion = 0.5
+
+// Rule is a key phrase that distinguishes a text from similar ones,
+// for example "or (at your option) any later version" for GPL-2.0-or-later.
+type Rule struct {
+	Kind   RuleKind
+	Phrase string
+}
+
+type phraseRule struct {
+	Rule
+	words []string
+}
+
+// buildRules tokenizes phrases of the text rules, phrases without tokens
+// like "" or "," are dropped as they would fire on any text.
+func buildRules(text Text, tokenizer Tokenizer) []phraseRule {
+	var rules []phraseRule
+
+	add := func(kind RuleKind, phrases []string) {
+		for _, phrase := range phrases {
+			words := tokenizer.Tokenize(phrase)
+			if len(words) == 0 {
+				continue
+			}
+
+			rules = append(rules, phraseRule{
+				Rule:  Rule{Kind: kind, Phrase: phrase},
+				words: words,
+			})
+		}
+	}
+
+	add(RuleRequired, text.Required)
+	add(RuleForbidden, text.Forbidden)
+
+	return rules
+}
+
+// firedRules returns rules which fire on the tokenized text.
+func firedRules(rules []phraseRule, words []string) []Rule {
+	var fired []Rule
+
+	for _, rule := range rules {
+		// An empty phrase is contained in any text, so a forbidden one would always fire.
+		found := containsPhrase(words, rule.words) && !(rule.Kind == RuleForbidden && len(rule.words) == 0)
+
+		if found == (rule.Kind == RuleForbidden) {
+			fired = append(fired, rule.Rule)
+		}
+	}
+
+	return fired
+}
+
+// containsPhrase reports whether the phrase words appear in words consecutively.
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return true
+	}
+
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		matched := true
+
+		for j, word := range phrase {
+			if words[i+j] != word {
+				matched = false
+				break
+			}
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	return false
+}
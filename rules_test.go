@@ -0,0 +1,90 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gplTexts() []Text {
+	body := `This program is free software; you can redistribute it and/or modify
+	it under the terms of the GNU General Public License as published by
+	the Free Software Foundation; version 2 of the License`
+
+	return []Text{
+		{
+			Name:      "GPL-2.0-only",
+			Content:   body + ".",
+			Forbidden: []string{"or (at your option) any later"},
+		},
+		{
+			Name:     "GPL-2.0-or-later",
+			Content:  body + ", or (at your option) any later version.",
+			Required: []string{"or (at your option) any later"},
+		},
+	}
+}
+
+func TestMatcher_Rules(t *testing.T) {
+	matcher := NewTextMatcher(gplTexts()...)
+
+	t.Run("or_later", func(t *testing.T) {
+		result := matcher.Match(gplTexts()[1].Content)
+
+		assert.Equal(t, "GPL-2.0-only", result[0].TextName)
+		assert.Equal(t, []Rule{{Kind: RuleForbidden, Phrase: "or (at your option) any later"}}, result[0].Rules)
+
+		assert.Equal(t, "GPL-2.0-or-later", result[1].TextName)
+		assert.Nil(t, result[1].Rules)
+		assert.Equal(t, 1., result[1].Confidence)
+
+		assert.Greater(t, result[1].Confidence, result[0].Confidence)
+	})
+
+	t.Run("only", func(t *testing.T) {
+		result := matcher.Match(gplTexts()[0].Content)
+
+		assert.Nil(t, result[0].Rules)
+		assert.Equal(t, []Rule{{Kind: RuleRequired, Phrase: "or (at your option) any later"}}, result[1].Rules)
+
+		assert.Greater(t, result[0].Confidence, result[1].Confidence)
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		result := matcher.MatchWeighted(gplTexts()[0].Content)
+
+		assert.NotEmpty(t, result[1].Rules)
+		assert.Greater(t, result[0].Confidence, result[1].Confidence)
+	})
+}
+
+func TestMatcher_EmptyRules(t *testing.T) {
+	text := Text{Name: "mit", Content: "Permission is hereby granted, free of charge.", Forbidden: []string{"", ", ."}}
+
+	matcher := NewTextMatcherWithTokenizer(LicenseTokenizer(), text)
+	result := matcher.Match(text.Content)
+
+	assert.Nil(t, result[0].Rules)
+	assert.Equal(t, 1., result[0].Confidence)
+
+	assert.Empty(t, firedRules([]phraseRule{{Rule: Rule{Kind: RuleForbidden}}}, []string{"a"}))
+}
+
+func TestContainsPhrase(t *testing.T) {
+	testcases := map[string]struct {
+		words, phrase []string
+		expected      bool
+	}{
+		"middle":      {words: []string{"a", "b", "c", "d"}, phrase: []string{"b", "c"}, expected: true},
+		"end":         {words: []string{"a", "b", "c", "d"}, phrase: []string{"c", "d"}, expected: true},
+		"not_ordered": {words: []string{"a", "b", "c", "d"}, phrase: []string{"c", "b"}, expected: false},
+		"longer":      {words: []string{"a", "b"}, phrase: []string{"a", "b", "c"}, expected: false},
+		"empty":       {words: []string{"a"}, phrase: []string{}, expected: true},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, containsPhrase(tc.words, tc.phrase))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/radikh/compare/spdx"
+)
+
+type cdxBOM struct {
+	BOMFormat    string         `json:"bomFormat"`
+	SpecVersion  string         `json:"specVersion"`
+	SerialNumber string         `json:"serialNumber"`
+	Version      int            `json:"version"`
+	Metadata     cdxMetadata    `json:"metadata"`
+	Components   []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref,omitempty"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	Licenses   []cdxLicense  `json:"licenses,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxLicense struct {
+	License    *cdxLicenseID `json:"license,omitempty"`
+	Expression string        `json:"expression,omitempty"`
+}
+
+// cdxLicenseID is a single license, ID is an SPDX license identifier
+// and Name is used for other licenses.
+type cdxLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WriteCycloneDX writes the document as a CycloneDX 1.5 JSON bill of materials.
+// The matched file and the confidence are component properties.
+// Known SPDX license identifiers are written as ids, valid SPDX expressions
+// as expressions and other licenses by name.
+func WriteCycloneDX(w io.Writer, d Document) error {
+	bom := cdxBOM{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: d.serialNumber(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: d.created(),
+			Tools: cdxTools{
+				Components: []cdxComponent{{Type: "application", Name: d.Tool}},
+			},
+			Component: cdxComponent{Type: "application", Name: d.Name},
+		},
+		Components: make([]cdxComponent, 0, len(d.Packages)),
+	}
+
+	for i, pkg := range d.Packages {
+		component := cdxComponent{
+			Type:    "library",
+			BOMRef:  pkg.spdxID(i),
+			Name:    pkg.Name,
+			Version: pkg.Version,
+		}
+
+		if pkg.License != "" {
+			component.Licenses = []cdxLicense{cycloneDXLicense(pkg.License)}
+		}
+
+		if pkg.File != "" {
+			component.Properties = []cdxProperty{
+				{Name: "compare:license-file", Value: pkg.File},
+				{Name: "compare:confidence", Value: fmt.Sprintf("%.4f", pkg.Confidence)},
+			}
+		}
+
+		bom.Components = append(bom.Components, component)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(bom)
+}
+
+// cycloneDXLicense converts the concluded license to a CycloneDX license choice.
+func cycloneDXLicense(license string) cdxLicense {
+	expression, err := spdx.Parse(license)
+	if err != nil || spdx.Validate(expression) != nil {
+		return cdxLicense{License: &cdxLicenseID{Name: license}}
+	}
+
+	expression = spdx.Normalize(expression)
+
+	single, ok := expression.(spdx.License)
+	if !ok || single.OrLater {
+		return cdxLicense{Expression: expression.String()}
+	}
+
+	if _, known := spdx.LookupLicense(single.ID); known {
+		return cdxLicense{License: &cdxLicenseID{ID: single.ID}}
+	}
+
+	return cdxLicense{License: &cdxLicenseID{Name: license}}
+}
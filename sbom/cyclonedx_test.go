@@ -0,0 +1,117 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCycloneDX(t *testing.T) {
+	var buf bytes.Buffer
+
+	document := dummyDocument()
+	document.Packages = append(document.Packages, Package{
+		Name:       "example.com/dual",
+		Version:    "v2.0.0",
+		License:    "MIT OR Apache-2.0",
+		File:       "LICENSE",
+		Confidence: 1,
+	}, Package{
+		Name:       "example.com/proprietary",
+		Version:    "v3.0.0",
+		License:    "LicenseRef-Proprietary",
+		File:       "COPYING",
+		Confidence: 0.9,
+	}, Package{
+		Name:       "example.com/custom",
+		Version:    "v4.0.0",
+		License:    "Apache 2.0 custom",
+		File:       "LICENSE",
+		Confidence: 0.8,
+	}, Package{
+		Name:       "example.com/lower",
+		Version:    "v5.0.0",
+		License:    "mit",
+		File:       "LICENSE",
+		Confidence: 1,
+	})
+
+	require.NoError(t, WriteCycloneDX(&buf, document))
+
+	assert.JSONEq(t, `{
+		"bomFormat": "CycloneDX",
+		"specVersion": "1.5",
+		"serialNumber": "`+document.serialNumber()+`",
+		"version": 1,
+		"metadata": {
+			"timestamp": "2023-05-01T12:00:00Z",
+			"tools": {"components": [{"type": "application", "name": "licensescan"}]},
+			"component": {"type": "application", "name": "example"}
+		},
+		"components": [
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-1-github.com-stretchr-testify",
+				"name": "github.com/stretchr/testify",
+				"version": "v1.8.2",
+				"licenses": [{"license": {"id": "MIT"}}],
+				"properties": [
+					{"name": "compare:license-file", "value": "LICENSE"},
+					{"name": "compare:confidence", "value": "0.9800"}
+				]
+			},
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-2-example.com-unknown",
+				"name": "example.com/unknown",
+				"version": "v0.1.0"
+			},
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-3-example.com-dual",
+				"name": "example.com/dual",
+				"version": "v2.0.0",
+				"licenses": [{"expression": "MIT OR Apache-2.0"}],
+				"properties": [
+					{"name": "compare:license-file", "value": "LICENSE"},
+					{"name": "compare:confidence", "value": "1.0000"}
+				]
+			},
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-4-example.com-proprietary",
+				"name": "example.com/proprietary",
+				"version": "v3.0.0",
+				"licenses": [{"license": {"name": "LicenseRef-Proprietary"}}],
+				"properties": [
+					{"name": "compare:license-file", "value": "COPYING"},
+					{"name": "compare:confidence", "value": "0.9000"}
+				]
+			},
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-5-example.com-custom",
+				"name": "example.com/custom",
+				"version": "v4.0.0",
+				"licenses": [{"license": {"name": "Apache 2.0 custom"}}],
+				"properties": [
+					{"name": "compare:license-file", "value": "LICENSE"},
+					{"name": "compare:confidence", "value": "0.8000"}
+				]
+			},
+			{
+				"type": "library",
+				"bom-ref": "SPDXRef-Package-6-example.com-lower",
+				"name": "example.com/lower",
+				"version": "v5.0.0",
+				"licenses": [{"license": {"id": "MIT"}}],
+				"properties": [
+					{"name": "compare:license-file", "value": "LICENSE"},
+					{"name": "compare:confidence", "value": "1.0000"}
+				]
+			}
+		]
+	}`, buf.String())
+}
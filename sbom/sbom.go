@@ -0,0 +1,216 @@
+// Package sbom writes software bills of materials with licenses found by scans.
+// It supports SPDX 2.3 in tag-value and JSON formats and CycloneDX 1.5 JSON.
+// Each package carries its concluded license, the matched license file
+// and the confidence of the match as an annotation.
+package sbom
+
+import (
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // SHA-1 is used for name based UUIDs as RFC 4122 defines
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/scan"
+	"github.com/radikh/compare/spdx"
+)
+
+// NoAssertion is the SPDX value for unknown fields.
+const NoAssertion = "NOASSERTION"
+
+// Package is a dependency described by the bill of materials.
+type Package struct {
+	Name    string
+	Version string
+	// DownloadLocation is NOASSERTION when it's empty.
+	DownloadLocation string
+	// License is the concluded license, usually a corpus text name. It is written
+	// as a canonical SPDX expression, names which are not valid expressions
+	// become LicenseRef references. NOASSERTION is written when it's empty.
+	License string
+	// File is the path of the file the license was concluded from.
+	File string
+	// Confidence is the confidence of the license match.
+	Confidence float64
+}
+
+// Document is a bill of materials.
+type Document struct {
+	Name string
+	// Namespace is the unique URI of the document, for example
+	// https://example.com/sbom/myproject-v1.2.3. SPDX documents require it,
+	// CycloneDX serial numbers are random when it's empty.
+	Namespace string
+	// Tool is the name of the tool which created the document.
+	Tool     string
+	Created  time.Time
+	Packages []Package
+}
+
+// NewPackage creates a package with the license of the best license file finding.
+// Source headers are used only when the package has no license files.
+func NewPackage(name, version string, findings []scan.Finding) Package {
+	pkg := Package{Name: name, Version: version}
+
+	bestKind := scan.Kind("")
+
+	for _, finding := range findings {
+		best, ok := finding.Best()
+		if !ok {
+			continue
+		}
+
+		better := bestKind == "" ||
+			finding.Kind == scan.KindLicenseFile && bestKind != scan.KindLicenseFile ||
+			finding.Kind == bestKind && best.Confidence > pkg.Confidence
+
+		if better {
+			pkg.License = best.TextName
+			pkg.File = finding.Path
+			pkg.Confidence = best.Confidence
+			bestKind = finding.Kind
+		}
+	}
+
+	return pkg
+}
+
+// expression returns the SPDX expression of the concluded license, nil when it's empty.
+func (p Package) expression() spdx.Expression {
+	if p.License == "" {
+		return nil
+	}
+
+	return spdx.FromMatches([]compare.Match{{TextName: p.License, Confidence: 1}}, 0)
+}
+
+func (p Package) license() string {
+	expression := p.expression()
+	if expression == nil {
+		return NoAssertion
+	}
+
+	return expression.String()
+}
+
+// extractedLicense is a license of the document which is not in the SPDX License List.
+type extractedLicense struct {
+	ID   string
+	Name string
+}
+
+// extractedLicenses returns LicenseRef references used by packages of the document in order
+// of appearance. The name is the concluded license of the package it was built from.
+func (d Document) extractedLicenses() []extractedLicense {
+	result := []extractedLicense{}
+	seen := map[string]bool{}
+
+	for _, pkg := range d.Packages {
+		expression := pkg.expression()
+
+		for _, id := range licenseRefs(expression) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			name := id
+			if license, ok := expression.(spdx.License); ok && license.ID == id {
+				name = pkg.License
+			}
+
+			result = append(result, extractedLicense{ID: id, Name: name})
+		}
+	}
+
+	return result
+}
+
+// licenseRefs returns user defined license references of the expression.
+func licenseRefs(expression spdx.Expression) []string {
+	switch e := expression.(type) {
+	case spdx.License:
+		if strings.HasPrefix(e.ID, "LicenseRef-") {
+			return []string{e.ID}
+		}
+	case spdx.With:
+		return licenseRefs(e.License)
+	case spdx.And:
+		return termsLicenseRefs(e.Terms)
+	case spdx.Or:
+		return termsLicenseRefs(e.Terms)
+	}
+
+	return nil
+}
+
+func termsLicenseRefs(terms []spdx.Expression) []string {
+	var refs []string
+
+	for _, term := range terms {
+		refs = append(refs, licenseRefs(term)...)
+	}
+
+	return refs
+}
+
+func (p Package) downloadLocation() string {
+	if p.DownloadLocation == "" {
+		return NoAssertion
+	}
+
+	return p.DownloadLocation
+}
+
+// annotation describes how the license of the package was concluded.
+func (p Package) annotation() string {
+	if p.File == "" {
+		return "license was not detected"
+	}
+
+	return fmt.Sprintf("license concluded from %s with confidence %.4f", p.File, p.Confidence)
+}
+
+// spdxID returns the SPDX identifier of the package at the index of the document.
+func (p Package) spdxID(index int) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, p.Name)
+
+	return fmt.Sprintf("SPDXRef-Package-%d-%s", index+1, name)
+}
+
+// serialNumber returns the name based UUID URN of the document derived from its namespace,
+// or a random UUID URN when the document has no namespace.
+func (d Document) serialNumber() string {
+	if d.Namespace == "" {
+		var uuid [16]byte
+		_, _ = rand.Read(uuid[:])
+
+		uuid[6] = (uuid[6] & 0x0f) | 0x40
+		uuid[8] = (uuid[8] & 0x3f) | 0x80
+
+		return formatUUID(uuid[:])
+	}
+
+	sum := sha1.Sum([]byte(d.Namespace)) //nolint:gosec // see the import comment
+
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	return formatUUID(sum[:16])
+}
+
+func formatUUID(uuid []byte) string {
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
+func (d Document) created() string {
+	return d.Created.UTC().Format(time.RFC3339)
+}
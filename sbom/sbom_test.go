@@ -0,0 +1,99 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radikh/compare"
+	"github.com/radikh/compare/scan"
+	"github.com/stretchr/testify/assert"
+)
+
+func dummyDocument() Document {
+	return Document{
+		Name:      "example",
+		Namespace: "https://example.com/sbom/example-v1.0.0",
+		Tool:      "licensescan",
+		Created:   time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC),
+		Packages: []Package{
+			{
+				Name:       "github.com/stretchr/testify",
+				Version:    "v1.8.2",
+				License:    "MIT",
+				File:       "LICENSE",
+				Confidence: 0.98,
+			},
+			{
+				Name:    "example.com/unknown",
+				Version: "v0.1.0",
+			},
+		},
+	}
+}
+
+func TestNewPackage(t *testing.T) {
+	findings := []scan.Finding{
+		{
+			Path:    "main.go",
+			Kind:    scan.KindSourceHeader,
+			Matches: []compare.Match{{TextName: "ISC", Confidence: 1}},
+		},
+		{
+			Path:    "LICENSE",
+			Kind:    scan.KindLicenseFile,
+			Matches: []compare.Match{{TextName: "MIT", Confidence: 0.9}},
+		},
+		{
+			Path:    "COPYING",
+			Kind:    scan.KindLicenseFile,
+			Matches: []compare.Match{{TextName: "BSD-2-Clause", Confidence: 0.95}},
+		},
+		{
+			Path:    "NOTICE",
+			Kind:    scan.KindLicenseFile,
+			Matches: []compare.Match{},
+		},
+	}
+
+	t.Run("license_files_first", func(t *testing.T) {
+		pkg := NewPackage("example.com/lib", "v1.0.0", findings)
+
+		assert.Equal(t, Package{
+			Name:       "example.com/lib",
+			Version:    "v1.0.0",
+			License:    "BSD-2-Clause",
+			File:       "COPYING",
+			Confidence: 0.95,
+		}, pkg)
+	})
+
+	t.Run("headers_only", func(t *testing.T) {
+		pkg := NewPackage("example.com/lib", "v1.0.0", findings[:1])
+
+		assert.Equal(t, "ISC", pkg.License)
+		assert.Equal(t, "main.go", pkg.File)
+	})
+
+	t.Run("nothing_found", func(t *testing.T) {
+		pkg := NewPackage("example.com/lib", "v1.0.0", findings[3:])
+
+		assert.Equal(t, Package{Name: "example.com/lib", Version: "v1.0.0"}, pkg)
+	})
+}
+
+func TestDocument_SerialNumber(t *testing.T) {
+	document := dummyDocument()
+
+	serial := document.serialNumber()
+
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, serial)
+	assert.Equal(t, serial, dummyDocument().serialNumber())
+
+	document.Namespace += "-next"
+	assert.NotEqual(t, serial, document.serialNumber())
+
+	document.Namespace = ""
+	random := document.serialNumber()
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, random)
+	assert.NotEqual(t, random, document.serialNumber())
+}
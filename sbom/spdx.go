@@ -0,0 +1,171 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	spdxVersion     = "SPDX-2.3"
+	spdxDataLicense = "CC0-1.0"
+	spdxDocumentID  = "SPDXRef-DOCUMENT"
+)
+
+// WriteSPDXTagValue writes the document in the SPDX 2.3 tag-value format.
+func WriteSPDXTagValue(w io.Writer, d Document) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "SPDXVersion: %s\n", spdxVersion)
+	fmt.Fprintf(&b, "DataLicense: %s\n", spdxDataLicense)
+	fmt.Fprintf(&b, "SPDXID: %s\n", spdxDocumentID)
+	fmt.Fprintf(&b, "DocumentName: %s\n", d.Name)
+	fmt.Fprintf(&b, "DocumentNamespace: %s\n", d.Namespace)
+	fmt.Fprintf(&b, "Creator: Tool: %s\n", d.Tool)
+	fmt.Fprintf(&b, "Created: %s\n", d.created())
+
+	for i, pkg := range d.Packages {
+		id := pkg.spdxID(i)
+
+		fmt.Fprintf(&b, "\nPackageName: %s\n", pkg.Name)
+		fmt.Fprintf(&b, "SPDXID: %s\n", id)
+		if pkg.Version != "" {
+			fmt.Fprintf(&b, "PackageVersion: %s\n", pkg.Version)
+		}
+		fmt.Fprintf(&b, "PackageDownloadLocation: %s\n", pkg.downloadLocation())
+		fmt.Fprintf(&b, "FilesAnalyzed: false\n")
+		fmt.Fprintf(&b, "PackageLicenseConcluded: %s\n", pkg.license())
+		fmt.Fprintf(&b, "PackageLicenseDeclared: %s\n", NoAssertion)
+		fmt.Fprintf(&b, "PackageCopyrightText: %s\n", NoAssertion)
+
+		fmt.Fprintf(&b, "\nAnnotator: Tool: %s\n", d.Tool)
+		fmt.Fprintf(&b, "AnnotationDate: %s\n", d.created())
+		fmt.Fprintf(&b, "AnnotationType: OTHER\n")
+		fmt.Fprintf(&b, "SPDXREF: %s\n", id)
+		fmt.Fprintf(&b, "AnnotationComment: <text>%s</text>\n", pkg.annotation())
+
+		fmt.Fprintf(&b, "\nRelationship: %s DESCRIBES %s\n", spdxDocumentID, id)
+	}
+
+	for _, license := range d.extractedLicenses() {
+		fmt.Fprintf(&b, "\nLicenseID: %s\n", license.ID)
+		fmt.Fprintf(&b, "ExtractedText: <text>%s</text>\n", license.text())
+		fmt.Fprintf(&b, "LicenseName: %s\n", license.Name)
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Relationships     []spdxRelationship `json:"relationships"`
+	// ExtractedLicenses are licenses referenced by LicenseRef identifiers.
+	ExtractedLicenses []spdxExtractedLicense `json:"hasExtractedLicensingInfos,omitempty"`
+}
+
+type spdxExtractedLicense struct {
+	LicenseID     string `json:"licenseId"`
+	ExtractedText string `json:"extractedText"`
+	Name          string `json:"name"`
+}
+
+// text returns the extracted text of the license. The document doesn't keep license
+// texts, so the text names the matched corpus text, as SPDX requires the field.
+func (l extractedLicense) text() string {
+	return fmt.Sprintf("License text matched the corpus text %q", l.Name)
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	Name             string           `json:"name"`
+	SPDXID           string           `json:"SPDXID"`
+	VersionInfo      string           `json:"versionInfo,omitempty"`
+	DownloadLocation string           `json:"downloadLocation"`
+	FilesAnalyzed    bool             `json:"filesAnalyzed"`
+	LicenseConcluded string           `json:"licenseConcluded"`
+	LicenseDeclared  string           `json:"licenseDeclared"`
+	CopyrightText    string           `json:"copyrightText"`
+	Annotations      []spdxAnnotation `json:"annotations"`
+}
+
+type spdxAnnotation struct {
+	AnnotationDate string `json:"annotationDate"`
+	AnnotationType string `json:"annotationType"`
+	Annotator      string `json:"annotator"`
+	Comment        string `json:"comment"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// WriteSPDXJSON writes the document in the SPDX 2.3 JSON format.
+func WriteSPDXJSON(w io.Writer, d Document) error {
+	document := spdxDocument{
+		SPDXVersion:       spdxVersion,
+		DataLicense:       spdxDataLicense,
+		SPDXID:            spdxDocumentID,
+		Name:              d.Name,
+		DocumentNamespace: d.Namespace,
+		CreationInfo: spdxCreationInfo{
+			Created:  d.created(),
+			Creators: []string{"Tool: " + d.Tool},
+		},
+		Packages:      make([]spdxPackage, 0, len(d.Packages)),
+		Relationships: make([]spdxRelationship, 0, len(d.Packages)),
+	}
+
+	for i, pkg := range d.Packages {
+		id := pkg.spdxID(i)
+
+		document.Packages = append(document.Packages, spdxPackage{
+			Name:             pkg.Name,
+			SPDXID:           id,
+			VersionInfo:      pkg.Version,
+			DownloadLocation: pkg.downloadLocation(),
+			LicenseConcluded: pkg.license(),
+			LicenseDeclared:  NoAssertion,
+			CopyrightText:    NoAssertion,
+			Annotations: []spdxAnnotation{{
+				AnnotationDate: d.created(),
+				AnnotationType: "OTHER",
+				Annotator:      "Tool: " + d.Tool,
+				Comment:        pkg.annotation(),
+			}},
+		})
+
+		document.Relationships = append(document.Relationships, spdxRelationship{
+			SPDXElementID:      spdxDocumentID,
+			RelationshipType:   "DESCRIBES",
+			RelatedSPDXElement: id,
+		})
+	}
+
+	for _, license := range d.extractedLicenses() {
+		document.ExtractedLicenses = append(document.ExtractedLicenses, spdxExtractedLicense{
+			LicenseID:     license.ID,
+			ExtractedText: license.text(),
+			Name:          license.Name,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(document)
+}
@@ -0,0 +1,145 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSPDXTagValue(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteSPDXTagValue(&buf, dummyDocument()))
+
+	assert.Equal(t, `SPDXVersion: SPDX-2.3
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: example
+DocumentNamespace: https://example.com/sbom/example-v1.0.0
+Creator: Tool: licensescan
+Created: 2023-05-01T12:00:00Z
+
+PackageName: github.com/stretchr/testify
+SPDXID: SPDXRef-Package-1-github.com-stretchr-testify
+PackageVersion: v1.8.2
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: MIT
+PackageLicenseDeclared: NOASSERTION
+PackageCopyrightText: NOASSERTION
+
+Annotator: Tool: licensescan
+AnnotationDate: 2023-05-01T12:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-1-github.com-stretchr-testify
+AnnotationComment: <text>license concluded from LICENSE with confidence 0.9800</text>
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-1-github.com-stretchr-testify
+
+PackageName: example.com/unknown
+SPDXID: SPDXRef-Package-2-example.com-unknown
+PackageVersion: v0.1.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: NOASSERTION
+PackageLicenseDeclared: NOASSERTION
+PackageCopyrightText: NOASSERTION
+
+Annotator: Tool: licensescan
+AnnotationDate: 2023-05-01T12:00:00Z
+AnnotationType: OTHER
+SPDXREF: SPDXRef-Package-2-example.com-unknown
+AnnotationComment: <text>license was not detected</text>
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-2-example.com-unknown
+`, buf.String())
+}
+
+func TestWriteSPDXTagValue_ExtractedLicenses(t *testing.T) {
+	var buf bytes.Buffer
+
+	document := dummyDocument()
+	document.Packages = []Package{
+		{Name: "a", License: "Apache 2.0 custom"},
+		{Name: "b", License: "MIT AND Apache 2.0 custom"},
+	}
+
+	require.NoError(t, WriteSPDXTagValue(&buf, document))
+
+	assert.Contains(t, buf.String(), "PackageLicenseConcluded: LicenseRef-Apache-2.0-custom\n")
+	assert.Contains(t, buf.String(), "PackageLicenseConcluded: LicenseRef-MIT-AND-Apache-2.0-custom\n")
+	assert.Contains(t, buf.String(), "\nLicenseID: LicenseRef-Apache-2.0-custom\n"+
+		"ExtractedText: <text>License text matched the corpus text \"Apache 2.0 custom\"</text>\n"+
+		"LicenseName: Apache 2.0 custom\n")
+	assert.Contains(t, buf.String(), "\nLicenseID: LicenseRef-MIT-AND-Apache-2.0-custom\n")
+}
+
+func TestWriteSPDXJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	document := dummyDocument()
+	document.Packages = []Package{
+		{Name: "github.com/stretchr/testify", Version: "v1.8.2", License: "mit", File: "LICENSE", Confidence: 0.98},
+		{Name: "example.com/custom", License: "Apache 2.0 custom", File: "LICENSE", Confidence: 0.8},
+	}
+
+	require.NoError(t, WriteSPDXJSON(&buf, document))
+
+	assert.JSONEq(t, `{
+		"spdxVersion": "SPDX-2.3",
+		"dataLicense": "CC0-1.0",
+		"SPDXID": "SPDXRef-DOCUMENT",
+		"name": "example",
+		"documentNamespace": "https://example.com/sbom/example-v1.0.0",
+		"creationInfo": {
+			"created": "2023-05-01T12:00:00Z",
+			"creators": ["Tool: licensescan"]
+		},
+		"packages": [{
+			"name": "github.com/stretchr/testify",
+			"SPDXID": "SPDXRef-Package-1-github.com-stretchr-testify",
+			"versionInfo": "v1.8.2",
+			"downloadLocation": "NOASSERTION",
+			"filesAnalyzed": false,
+			"licenseConcluded": "MIT",
+			"licenseDeclared": "NOASSERTION",
+			"copyrightText": "NOASSERTION",
+			"annotations": [{
+				"annotationDate": "2023-05-01T12:00:00Z",
+				"annotationType": "OTHER",
+				"annotator": "Tool: licensescan",
+				"comment": "license concluded from LICENSE with confidence 0.9800"
+			}]
+		}, {
+			"name": "example.com/custom",
+			"SPDXID": "SPDXRef-Package-2-example.com-custom",
+			"downloadLocation": "NOASSERTION",
+			"filesAnalyzed": false,
+			"licenseConcluded": "LicenseRef-Apache-2.0-custom",
+			"licenseDeclared": "NOASSERTION",
+			"copyrightText": "NOASSERTION",
+			"annotations": [{
+				"annotationDate": "2023-05-01T12:00:00Z",
+				"annotationType": "OTHER",
+				"annotator": "Tool: licensescan",
+				"comment": "license concluded from LICENSE with confidence 0.8000"
+			}]
+		}],
+		"relationships": [{
+			"spdxElementId": "SPDXRef-DOCUMENT",
+			"relationshipType": "DESCRIBES",
+			"relatedSpdxElement": "SPDXRef-Package-1-github.com-stretchr-testify"
+		}, {
+			"spdxElementId": "SPDXRef-DOCUMENT",
+			"relationshipType": "DESCRIBES",
+			"relatedSpdxElement": "SPDXRef-Package-2-example.com-custom"
+		}],
+		"hasExtractedLicensingInfos": [{
+			"licenseId": "LicenseRef-Apache-2.0-custom",
+			"extractedText": "License text matched the corpus text \"Apache 2.0 custom\"",
+			"name": "Apache 2.0 custom"
+		}]
+	}`, buf.String())
+}
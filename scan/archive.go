@@ -0,0 +1,258 @@
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/radikh/compare"
+)
+
+// archiveSeparator separates the path of an archive from the path inside it,
+// for example "deps/foo.zip!/LICENSE".
+const archiveSeparator = "!/"
+
+// DefaultMaxNestedArchiveSize limits the size of nested archives read in memory
+// when Options.MaxSize is zero.
+const DefaultMaxNestedArchiveSize = 64 << 20
+
+type archiveFormat int
+
+const (
+	formatNone archiveFormat = iota
+	formatZip
+	formatTar
+	formatTarGzip
+)
+
+// detectArchive returns the archive format by the file name.
+// Jars and other zip based packages are treated as zip archives.
+func detectArchive(name string) archiveFormat {
+	name = strings.ToLower(name)
+
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return formatTarGzip
+	case strings.HasSuffix(name, ".tar"):
+		return formatTar
+	}
+
+	switch path.Ext(name) {
+	case ".zip", ".jar", ".war", ".ear", ".aar", ".whl", ".nupkg":
+		return formatZip
+	default:
+		return formatNone
+	}
+}
+
+// ScanArchive scans license files and source headers inside the archive file
+// without extracting it to disk. Findings have virtual paths like "foo.zip!/LICENSE".
+// It fails when the archive can't be read, unreadable nested archives are reported
+// as findings of KindUnreadableArchive.
+// Nested archives are scanned up to the ArchiveDepth option,
+// the archive itself is scanned even when the option is zero.
+// Zip based archives (zip, jar, Go module zips), tar and gzipped tar archives are supported.
+func (s *Scanner) ScanArchive(filename string) ([]Finding, error) {
+	return s.scanArchiveFile(filename, filename, 1)
+}
+
+func (s *Scanner) scanArchiveFile(filename, virtualPath string, depth int) ([]Finding, error) {
+	format := detectArchive(filename)
+	if format == formatNone {
+		return nil, fmt.Errorf("%s: unknown archive format", virtualPath)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scanArchive(format, virtualPath, file, info.Size(), depth)
+}
+
+// archiveReader is a random access reader of an archive content.
+type archiveReader interface {
+	io.Reader
+	io.ReaderAt
+}
+
+func (s *Scanner) scanArchive(format archiveFormat, virtualPath string, r archiveReader, size int64, depth int) ([]Finding, error) {
+	var (
+		findings []Finding
+		err      error
+	)
+
+	switch format {
+	case formatZip:
+		findings, err = s.scanZip(virtualPath, r, size, depth)
+	case formatTar:
+		findings, err = s.scanTar(virtualPath, r, depth)
+	case formatTarGzip:
+		var gz *gzip.Reader
+
+		gz, err = gzip.NewReader(r)
+		if err == nil {
+			defer gz.Close()
+
+			findings, err = s.scanTar(virtualPath, gz, depth)
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", virtualPath, err)
+	}
+
+	return findings, nil
+}
+
+func (s *Scanner) scanZip(virtualPath string, r io.ReaderAt, size int64, depth int) ([]Finding, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []Finding{}
+
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		file := file
+		open := func() (io.ReadCloser, error) {
+			return file.Open()
+		}
+
+		entryFindings, err := s.scanEntry(virtualPath, file.Name, int64(file.UncompressedSize64), open, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		findings = append(findings, entryFindings...)
+	}
+
+	return findings, nil
+}
+
+func (s *Scanner) scanTar(virtualPath string, r io.Reader, depth int) ([]Finding, error) {
+	archive := tar.NewReader(r)
+	findings := []Finding{}
+
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		open := func() (io.ReadCloser, error) {
+			return io.NopCloser(archive), nil
+		}
+
+		entryFindings, err := s.scanEntry(virtualPath, header.Name, header.Size, open, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		findings = append(findings, entryFindings...)
+	}
+
+	return findings, nil
+}
+
+// scanEntry scans a file of an archive, nested archives are read in memory and scanned recursively.
+func (s *Scanner) scanEntry(archivePath, name string, size int64, open func() (io.ReadCloser, error), depth int) ([]Finding, error) {
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	virtualPath := archivePath + archiveSeparator + name
+
+	format := detectArchive(name)
+	nested := format != formatNone && depth < s.options.ArchiveDepth
+
+	limit := s.options.MaxSize
+	if nested && limit == 0 {
+		limit = DefaultMaxNestedArchiveSize
+	}
+
+	if s.exclude.match(name) || limit > 0 && size > limit {
+		return nil, nil
+	}
+
+	if !nested && !s.isCandidate(name) {
+		return nil, nil
+	}
+
+	rc, err := open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", virtualPath, err)
+	}
+	defer rc.Close()
+
+	var r io.Reader = rc
+	if limit > 0 {
+		// The size of an entry is declared by the archive, so it's not trusted.
+		r = io.LimitReader(rc, limit+1)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", virtualPath, err)
+	}
+
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, nil
+	}
+
+	if nested {
+		findings, err := s.scanArchive(format, virtualPath, bytes.NewReader(content), int64(len(content)), depth+1)
+		if err != nil {
+			return []Finding{unreadableArchive(virtualPath)}, nil
+		}
+
+		return findings, nil
+	}
+
+	return s.ScanContent(virtualPath, content), nil
+}
+
+// unreadableArchive returns the finding of an archive which couldn't be read.
+func unreadableArchive(virtualPath string) Finding {
+	return Finding{
+		Path:    virtualPath,
+		Kind:    KindUnreadableArchive,
+		Matches: []compare.Match{},
+	}
+}
+
+// isCandidate reports whether a file inside an archive may contain a license text.
+func (s *Scanner) isCandidate(name string) bool {
+	if len(s.include) > 0 && !s.include.match(name) {
+		return false
+	}
+
+	if isLicenseFile(name) {
+		return true
+	}
+
+	_, ok := sourceCommentStyle(name)
+
+	return ok
+}
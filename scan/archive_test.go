@@ -0,0 +1,220 @@
+package scan
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveFile is a file of a test archive, names are kept in order.
+type archiveFile struct {
+	name, content string
+}
+
+func zipArchive(t *testing.T, files ...archiveFile) string {
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+	for _, file := range files {
+		f, err := w.Create(file.name)
+		require.NoError(t, err)
+
+		_, err = f.Write([]byte(file.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	return buf.String()
+}
+
+func tarArchive(t *testing.T, compressed bool, files ...archiveFile) string {
+	var buf bytes.Buffer
+
+	var gz *gzip.Writer
+	w := tar.NewWriter(&buf)
+	if compressed {
+		gz = gzip.NewWriter(&buf)
+		w = tar.NewWriter(gz)
+	}
+
+	for _, file := range files {
+		require.NoError(t, w.WriteHeader(&tar.Header{
+			Name:     file.name,
+			Mode:     0o644,
+			Size:     int64(len(file.content)),
+			Typeflag: tar.TypeReg,
+		}))
+
+		_, err := w.Write([]byte(file.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	if gz != nil {
+		require.NoError(t, gz.Close())
+	}
+
+	return buf.String()
+}
+
+func findingLicenses(findings []Finding) map[string]string {
+	result := map[string]string{}
+	for _, finding := range findings {
+		best, _ := finding.Best()
+		result[finding.Path] = best.TextName
+	}
+
+	return result
+}
+
+func TestDetectArchive(t *testing.T) {
+	tests := map[string]archiveFormat{
+		"foo.zip":                     formatZip,
+		"lib/Foo.JAR":                 formatZip,
+		"example.com/mod/@v/v1.0.zip": formatZip,
+		"foo.tar":                     formatTar,
+		"foo.tar.gz":                  formatTarGzip,
+		"foo.tgz":                     formatTarGzip,
+		"foo.gz":                      formatNone,
+		"LICENSE":                     formatNone,
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, detectArchive(name), name)
+	}
+}
+
+func TestScanner_ScanArchive(t *testing.T) {
+	inner := zipArchive(t,
+		archiveFile{"META-INF/LICENSE", readLicense(t, "BSD-2-Clause")},
+		archiveFile{"Main.class", "\xca\xfe\xba\xbe"},
+	)
+
+	module := zipArchive(t,
+		archiveFile{"example.com/mod@v1.0.0/LICENSE", readLicense(t, "MIT")},
+		archiveFile{"example.com/mod@v1.0.0/main.go", commented(readLicense(t, "ISC"))},
+		archiveFile{"example.com/mod@v1.0.0/util.go", "package main\n"},
+		archiveFile{"example.com/mod@v1.0.0/lib/dep.jar", inner},
+	)
+
+	root := writeTree(t, map[string]string{
+		"LICENSE":                      readLicense(t, "MIT"),
+		"cache/download/mod/@v/v1.zip": module,
+		"dist/app.tar.gz": tarArchive(t, true,
+			archiveFile{"app/COPYING", readLicense(t, "ISC")},
+			archiveFile{"app/bundle.tar", tarArchive(t, false, archiveFile{"NOTICE", readLicense(t, "MIT")})},
+		),
+		"dist/broken.zip":    "not a zip archive",
+		"dist/truncated.zip": module[:len(module)/2],
+		"dist/wrapper.zip":   zipArchive(t, archiveFile{"inner.zip", "not a zip archive"}),
+	})
+
+	t.Run("depth", func(t *testing.T) {
+		tests := map[string]struct {
+			depth    int
+			expected map[string]string
+		}{
+			"disabled": {
+				depth:    0,
+				expected: map[string]string{"LICENSE": "MIT"},
+			},
+			"top_level": {
+				depth: 1,
+				expected: map[string]string{
+					"LICENSE": "MIT",
+					"cache/download/mod/@v/v1.zip!/example.com/mod@v1.0.0/LICENSE": "MIT",
+					"cache/download/mod/@v/v1.zip!/example.com/mod@v1.0.0/main.go": "ISC",
+					"dist/app.tar.gz!/app/COPYING":                                 "ISC",
+				},
+			},
+			"nested": {
+				depth: 2,
+				expected: map[string]string{
+					"LICENSE": "MIT",
+					"cache/download/mod/@v/v1.zip!/example.com/mod@v1.0.0/LICENSE":                       "MIT",
+					"cache/download/mod/@v/v1.zip!/example.com/mod@v1.0.0/main.go":                       "ISC",
+					"cache/download/mod/@v/v1.zip!/example.com/mod@v1.0.0/lib/dep.jar!/META-INF/LICENSE": "BSD-2-Clause",
+					"dist/app.tar.gz!/app/COPYING":                                                       "ISC",
+					"dist/app.tar.gz!/app/bundle.tar!/NOTICE":                                            "MIT",
+				},
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				scanner, err := New(licenseMatcher(t), Options{
+					Threshold:    0.5,
+					Exclude:      []string{"broken.zip", "truncated.zip", "wrapper.zip"},
+					ArchiveDepth: tt.depth,
+				})
+				require.NoError(t, err)
+
+				findings, err := scanner.Scan(root)
+				require.NoError(t, err)
+
+				assert.Equal(t, tt.expected, findingLicenses(findings))
+			})
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{Threshold: 0.5})
+		require.NoError(t, err)
+
+		archive := filepath.Join(root, "dist", "app.tar.gz")
+
+		findings, err := scanner.ScanArchive(archive)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{archive + "!/app/COPYING": "ISC"}, findingLicenses(findings))
+	})
+
+	t.Run("max_size", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{MaxSize: 100, ArchiveDepth: 2})
+		require.NoError(t, err)
+
+		findings, err := scanner.ScanArchive(filepath.Join(root, "dist", "app.tar.gz"))
+		require.NoError(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("broken", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{ArchiveDepth: 1})
+		require.NoError(t, err)
+
+		findings, err := scanner.Scan(root)
+		require.NoError(t, err)
+
+		kinds := map[string]Kind{}
+		for _, finding := range findings {
+			kinds[finding.Path] = finding.Kind
+		}
+
+		assert.Equal(t, KindLicenseFile, kinds["LICENSE"])
+		assert.Equal(t, KindUnreadableArchive, kinds["dist/broken.zip"])
+		assert.Equal(t, KindUnreadableArchive, kinds["dist/truncated.zip"])
+
+		nested, err := New(licenseMatcher(t), Options{ArchiveDepth: 2})
+		require.NoError(t, err)
+
+		findings, err = nested.ScanArchive(filepath.Join(root, "dist", "wrapper.zip"))
+		require.NoError(t, err)
+		assert.Equal(t, []Finding{unreadableArchive(filepath.Join(root, "dist", "wrapper.zip") + "!/inner.zip")}, findings)
+
+		_, err = scanner.ScanArchive(filepath.Join(root, "dist", "truncated.zip"))
+		assert.Error(t, err)
+
+		_, err = scanner.ScanArchive(filepath.Join(root, "LICENSE"))
+		assert.Error(t, err)
+
+		_, err = scanner.ScanArchive(filepath.Join(root, "missing.zip"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
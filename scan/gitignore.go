@@ -0,0 +1,199 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreRule is a single pattern of a .gitignore file.
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreList contains rules of a .gitignore file, they are relative to the base directory.
+type ignoreList struct {
+	base  string
+	rules []ignoreRule
+}
+
+// readIgnoreList reads the .gitignore file of the directory if any.
+// The base is the slash separated path of the directory relative to the scanned root.
+func readIgnoreList(dir, base string) (*ignoreList, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIgnoreList(content, base), nil
+}
+
+func parseIgnoreList(content []byte, base string) *ignoreList {
+	list := &ignoreList{base: base}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{}
+
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+
+		line = strings.TrimPrefix(line, `\`)
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		if line == "" {
+			continue
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+
+		pattern, err := compileGlob(line, anchored)
+		if err != nil {
+			continue
+		}
+
+		rule.pattern = pattern
+		list.rules = append(list.rules, rule)
+	}
+
+	return list
+}
+
+// match returns whether the rules decide on the path and whether the path is ignored.
+// The path is slash separated and relative to the scanned root.
+func (l *ignoreList) match(relPath string, isDir bool) (matched, ignored bool) {
+	if l.base != "" {
+		if !strings.HasPrefix(relPath, l.base+"/") {
+			return false, false
+		}
+		relPath = strings.TrimPrefix(relPath, l.base+"/")
+	}
+
+	for _, rule := range l.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		if rule.pattern.MatchString(relPath) {
+			matched, ignored = true, !rule.negate
+		}
+	}
+
+	return matched, ignored
+}
+
+// ignoreStack is a set of .gitignore files from the root to the current directory.
+type ignoreStack []*ignoreList
+
+func (s ignoreStack) ignored(relPath string, isDir bool) bool {
+	result := false
+
+	for _, list := range s {
+		if matched, ignored := list.match(relPath, isDir); matched {
+			result = ignored
+		}
+	}
+
+	return result
+}
+
+// compileGlob converts a gitignore style glob into a regular expression.
+// Anchored globs match the whole path, others match the path or any of its trailing parts.
+func compileGlob(glob string, anchored bool) (*regexp.Regexp, error) {
+	var expr strings.Builder
+
+	expr.WriteString("^")
+	if !anchored {
+		expr.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				expr.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				expr.WriteString(".*")
+				i++
+			} else {
+				expr.WriteString("[^/]*")
+			}
+		case '?':
+			expr.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+
+	return regexp.Compile(expr.String())
+}
+
+// globMatcher matches paths with a set of globs.
+// Globs with a slash are matched against the whole relative path, others against any part of it.
+type globMatcher []*regexp.Regexp
+
+func newGlobMatcher(globs []string) (globMatcher, error) {
+	matcher := make(globMatcher, 0, len(globs))
+
+	for _, glob := range globs {
+		anchored := strings.Contains(glob, "/")
+
+		pattern, err := compileGlob(path.Clean(strings.TrimPrefix(glob, "/")), anchored)
+		if err != nil {
+			return nil, err
+		}
+
+		matcher = append(matcher, pattern)
+	}
+
+	return matcher, nil
+}
+
+func (m globMatcher) match(relPath string) bool {
+	for _, pattern := range m {
+		if pattern.MatchString(relPath) {
+			return true
+		}
+	}
+
+	return false
+}
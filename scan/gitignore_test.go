@@ -0,0 +1,65 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreList(t *testing.T) {
+	content := []byte(`
+# comment
+*.log
+!keep.log
+build/
+/vendor
+docs/**/*.tmp
+`)
+
+	list := parseIgnoreList(content, "")
+
+	testcases := map[string]struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		"glob":               {path: "a/b/debug.log", ignored: true},
+		"negated":            {path: "a/keep.log", ignored: false},
+		"dir_only_dir":       {path: "a/build", isDir: true, ignored: true},
+		"dir_only_file":      {path: "a/build", ignored: false},
+		"anchored_root":      {path: "vendor", isDir: true, ignored: true},
+		"anchored_nested":    {path: "a/vendor", isDir: true, ignored: false},
+		"double_star":        {path: "docs/a/b/c.tmp", ignored: true},
+		"double_star_direct": {path: "docs/c.tmp", ignored: true},
+		"not_matching":       {path: "main.go", ignored: false},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			stack := ignoreStack{list}
+
+			assert.Equal(t, tc.ignored, stack.ignored(tc.path, tc.isDir))
+		})
+	}
+
+	t.Run("nested_list_is_relative", func(t *testing.T) {
+		nested := parseIgnoreList([]byte("/generated.go\n"), "pkg")
+		stack := ignoreStack{list, nested}
+
+		assert.True(t, stack.ignored("pkg/generated.go", false))
+		assert.False(t, stack.ignored("generated.go", false))
+		assert.False(t, stack.ignored("pkg/sub/generated.go", false))
+	})
+}
+
+func TestGlobMatcher(t *testing.T) {
+	matcher, err := newGlobMatcher([]string{"*.go", "docs/*.md", "[Ll]icense*"})
+	require.NoError(t, err)
+
+	assert.True(t, matcher.match("a/b/main.go"))
+	assert.True(t, matcher.match("docs/README.md"))
+	assert.False(t, matcher.match("a/docs/README.md"))
+	assert.True(t, matcher.match("a/License.txt"))
+	assert.False(t, matcher.match("main.py"))
+}
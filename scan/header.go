@@ -0,0 +1,174 @@
+package scan
+
+import (
+	"path"
+	"strings"
+)
+
+// licenseFileStems are lowercase names of files which usually contain license texts.
+func licenseFileStems() []string {
+	return []string{"license", "licence", "copying", "copyright", "notice", "unlicense", "patents"}
+}
+
+// isLicenseFile reports whether the file name looks like a license file,
+// for example LICENSE, LICENSE.md, LICENSE-MIT, COPYING or COPYING.LESSER.
+// Names may have a documentation extension, source files like copyright.go are never license files.
+func isLicenseFile(name string) bool {
+	name = strings.ToLower(path.Base(name))
+
+	if _, ok := sourceCommentStyle(name); ok {
+		return false
+	}
+
+	if strings.HasSuffix(name, ".license") || strings.HasPrefix(name, "copying.") {
+		return true
+	}
+
+	stem := name
+	switch path.Ext(name) {
+	case ".txt", ".md", ".rst":
+		stem = strings.TrimSuffix(name, path.Ext(name))
+	}
+
+	for _, licenseStem := range licenseFileStems() {
+		if stem == licenseStem || strings.HasPrefix(stem, licenseStem+"-") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commentStyle describes comments of a source language.
+type commentStyle struct {
+	line       []string
+	blockStart string
+	blockEnd   string
+}
+
+// sourceCommentStyle returns the comment style of a source file by its extension.
+func sourceCommentStyle(name string) (commentStyle, bool) {
+	cLike := commentStyle{line: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hash := commentStyle{line: []string{"#"}}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".go", ".c", ".h", ".cc", ".cpp", ".hpp", ".cxx", ".java", ".js", ".jsx", ".ts", ".tsx",
+		".cs", ".kt", ".kts", ".swift", ".scala", ".rs", ".dart", ".php", ".css", ".scss", ".proto", ".groovy":
+		return cLike, true
+	case ".py", ".rb", ".sh", ".bash", ".zsh", ".pl", ".pm", ".r", ".yaml", ".yml", ".toml", ".cmake", ".ps1":
+		return hash, true
+	case ".sql", ".lua", ".hs":
+		return commentStyle{line: []string{"--"}, blockStart: "/*", blockEnd: "*/"}, true
+	case ".el", ".lisp", ".clj", ".scm":
+		return commentStyle{line: []string{";;", ";"}}, true
+	case ".html", ".xml", ".svg", ".vue":
+		return commentStyle{blockStart: "<!--", blockEnd: "-->"}, true
+	default:
+		return commentStyle{}, false
+	}
+}
+
+// header is the leading comment of a source file.
+type header struct {
+	text      string
+	startLine int
+	endLine   int
+}
+
+// isBuildConstraint reports whether the line is a Go build constraint.
+func isBuildConstraint(line string) bool {
+	return strings.HasPrefix(line, "//go:build ") || strings.HasPrefix(line, "// +build ")
+}
+
+// extractHeader returns the first comment block of the source skipping
+// a shebang line, build constraints and blank lines before it.
+// Comment markers and decoration like leading asterisks are removed from the text.
+func extractHeader(source string, style commentStyle) (header, bool) {
+	lines := strings.Split(source, "\n")
+
+	result := header{}
+	text := []string{}
+	inBlock := false
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if inBlock {
+			end := strings.Index(trimmed, style.blockEnd)
+			if end >= 0 {
+				text = append(text, cleanCommentLine(trimmed[:end]))
+				result.endLine = i + 1
+				inBlock = false
+				continue
+			}
+
+			text = append(text, cleanCommentLine(trimmed))
+			continue
+		}
+
+		if len(text) == 0 && (trimmed == "" || i == 0 && strings.HasPrefix(trimmed, "#!") || isBuildConstraint(trimmed)) {
+			continue
+		}
+
+		if marker, ok := lineComment(trimmed, style); ok {
+			if len(text) == 0 {
+				result.startLine = i + 1
+			}
+
+			text = append(text, cleanCommentLine(strings.TrimPrefix(trimmed, marker)))
+			result.endLine = i + 1
+			continue
+		}
+
+		if style.blockStart != "" && strings.HasPrefix(trimmed, style.blockStart) {
+			if len(text) == 0 {
+				result.startLine = i + 1
+			}
+
+			rest := strings.TrimPrefix(trimmed, style.blockStart)
+			if end := strings.Index(rest, style.blockEnd); end >= 0 {
+				text = append(text, cleanCommentLine(rest[:end]))
+				result.endLine = i + 1
+				continue
+			}
+
+			text = append(text, cleanCommentLine(rest))
+			inBlock = true
+			continue
+		}
+
+		if len(text) == 0 && trimmed != "" {
+			return header{}, false
+		}
+
+		break
+	}
+
+	result.text = strings.TrimSpace(strings.Join(text, "\n"))
+	if result.text == "" {
+		return header{}, false
+	}
+
+	if inBlock {
+		result.endLine = len(lines)
+	}
+
+	return result, true
+}
+
+func lineComment(line string, style commentStyle) (string, bool) {
+	for _, marker := range style.line {
+		if strings.HasPrefix(line, marker) {
+			return marker, true
+		}
+	}
+
+	return "", false
+}
+
+func cleanCommentLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimLeft(line, "*")
+
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,95 @@
+package scan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLicenseFile(t *testing.T) {
+	for _, name := range []string{
+		"LICENSE", "a/LICENSE.md", "LICENCE", "COPYING.txt", "LICENSE-MIT", "NOTICE", "UNLICENSE", "foo.license",
+		"COPYING.LESSER", "LICENSE-APACHE.txt", "docs/LICENSE.rst",
+	} {
+		assert.True(t, isLicenseFile(name), name)
+	}
+
+	for _, name := range []string{
+		"main.go", "README.md", "licensing/main.go", "NOTES.md",
+		"copyright.go", "copyright_test.go", "spdx/licenses.txt", "license.json", "LICENSE.go", "COPYING.c",
+	} {
+		assert.False(t, isLicenseFile(name), name)
+	}
+}
+
+func TestExtractHeader(t *testing.T) {
+	goStyle, _ := sourceCommentStyle("main.go")
+	pyStyle, _ := sourceCommentStyle("main.py")
+
+	testcases := map[string]struct {
+		source   string
+		style    commentStyle
+		expected header
+		ok       bool
+	}{
+		"line_comments": {
+			source: "// Copyright 2020 Lorem.\n// Use of this source code is governed\n// by a license.\n\npackage main\n",
+			style:  goStyle,
+			expected: header{
+				text:      "Copyright 2020 Lorem.\nUse of this source code is governed\nby a license.",
+				startLine: 1,
+				endLine:   3,
+			},
+			ok: true,
+		},
+		"block_comment": {
+			source: "\n/*\n * Lorem ipsum\n * dolor sit amet\n */\npackage main\n",
+			style:  goStyle,
+			expected: header{
+				text:      "Lorem ipsum\ndolor sit amet",
+				startLine: 2,
+				endLine:   5,
+			},
+			ok: true,
+		},
+		"shebang": {
+			source: "#!/usr/bin/env python\n# Lorem ipsum\nimport os\n",
+			style:  pyStyle,
+			expected: header{
+				text:      "Lorem ipsum",
+				startLine: 2,
+				endLine:   2,
+			},
+			ok: true,
+		},
+		"build_constraints": {
+			source: "//go:build linux\n// +build linux\n\n// Copyright 2020 Foo\n// Permission is hereby granted.\n\npackage main\n",
+			style:  goStyle,
+			expected: header{
+				text:      "Copyright 2020 Foo\nPermission is hereby granted.",
+				startLine: 4,
+				endLine:   5,
+			},
+			ok: true,
+		},
+		"code_first": {
+			source: "package main\n// Lorem ipsum\n",
+			style:  goStyle,
+			ok:     false,
+		},
+		"empty": {
+			source: "",
+			style:  goStyle,
+			ok:     false,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			result, ok := extractHeader(tc.source, tc.style)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
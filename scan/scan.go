@@ -0,0 +1,295 @@
+// Package scan finds license texts in directory trees and classifies them with a TextMatcher.
+// It looks at license-like files such as LICENSE or COPYING and at leading comments
+// of source files, respecting .gitignore files and include and exclude globs.
+// Scanning works fully offline, the corpus of known texts is provided by the caller.
+package scan
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/radikh/compare"
+)
+
+// Kind is a kind of a place where a license text was found.
+type Kind string
+
+const (
+	// KindLicenseFile is a whole file with a license-like name.
+	KindLicenseFile Kind = "license-file"
+	// KindSourceHeader is a leading comment of a source file.
+	KindSourceHeader Kind = "source-header"
+	// KindUnreadableArchive is an archive which couldn't be read, for example a truncated zip.
+	// Its findings have no region and no matches.
+	KindUnreadableArchive Kind = "unreadable-archive"
+)
+
+// Region is a range of lines of a file, both ends are inclusive and start from 1.
+type Region struct {
+	StartLine int
+	EndLine   int
+}
+
+// Finding is a classified text found during the scan.
+type Finding struct {
+	// Path is a slash separated path relative to the scanned root.
+	Path   string
+	Kind   Kind
+	Region Region
+	// Matches are the matches above the threshold ordered by confidence descending.
+	Matches []compare.Match
+	// Decision is the calibrated decision of the best match.
+	Decision compare.Decision
+	// Ambiguous is set when another match is too close to the best one
+	// to conclude the license, see compare.Classification.
+	Ambiguous bool
+}
+
+// Best returns the best match of the finding, ok is false when nothing matched.
+func (f Finding) Best() (match compare.Match, ok bool) {
+	if len(f.Matches) == 0 {
+		return compare.Match{}, false
+	}
+
+	return f.Matches[0], true
+}
+
+// Options configures the scanner.
+type Options struct {
+	// Include globs limit scanned files to matching ones, all files are scanned if it's empty.
+	Include []string
+	// Exclude globs skip matching files and directories.
+	Exclude []string
+	// MaxSize is the maximum size of a scanned file in bytes, zero means no limit.
+	MaxSize int64
+	// Threshold is the minimal confidence of a reported match.
+	Threshold float64
+	// DisableGitignore makes the scanner ignore .gitignore files.
+	DisableGitignore bool
+	// ArchiveDepth is the number of archive levels to scan, zero means archives
+	// found in the directory tree are not opened, one means archives are scanned
+	// but archives nested in them are not. Nested archives are read in memory,
+	// so their size is limited by MaxSize or by DefaultMaxNestedArchiveSize when MaxSize is zero.
+	ArchiveDepth int
+}
+
+// Scanner classifies license texts found in files.
+type Scanner struct {
+	matcher *compare.TextMatcher
+	options Options
+	include globMatcher
+	exclude globMatcher
+}
+
+// New creates a scanner which classifies texts with the matcher.
+// It fails when include or exclude globs are malformed.
+func New(matcher *compare.TextMatcher, options Options) (*Scanner, error) {
+	include, err := newGlobMatcher(options.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := newGlobMatcher(options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scanner{
+		matcher: matcher,
+		options: options,
+		include: include,
+		exclude: exclude,
+	}, nil
+}
+
+// Scan walks the directory tree and classifies license files and source headers.
+// Findings are ordered by path.
+func (s *Scanner) Scan(root string) ([]Finding, error) {
+	findings := []Finding{}
+	ignores := map[string]ignoreStack{}
+
+	err := filepath.WalkDir(root, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(root, fullPath)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+
+		if d.IsDir() {
+			return s.enterDir(fullPath, relPath, d, ignores)
+		}
+
+		parent := filepath.ToSlash(filepath.Dir(relPath))
+
+		if s.options.ArchiveDepth > 0 && detectArchive(relPath) != formatNone && d.Type().IsRegular() {
+			if s.exclude.match(relPath) || !s.options.DisableGitignore && ignores[parent].ignored(relPath, false) {
+				return nil
+			}
+
+			archiveFindings, err := s.scanArchiveFile(fullPath, relPath, 1)
+			if err != nil {
+				archiveFindings = []Finding{unreadableArchive(relPath)}
+			}
+
+			findings = append(findings, archiveFindings...)
+
+			return nil
+		}
+
+		if s.skipFile(relPath, ignores[parent]) || !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if s.options.MaxSize > 0 && info.Size() > s.options.MaxSize {
+			return nil
+		}
+
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			return err
+		}
+
+		findings = append(findings, s.ScanContent(relPath, content)...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Path < findings[j].Path
+	})
+
+	return findings, nil
+}
+
+// enterDir decides whether the directory is walked and loads its .gitignore rules.
+func (s *Scanner) enterDir(fullPath, relPath string, d fs.DirEntry, ignores map[string]ignoreStack) error {
+	stack := ignoreStack{}
+
+	if relPath != "." {
+		if d.Name() == ".git" || s.exclude.match(relPath) {
+			return filepath.SkipDir
+		}
+
+		stack = ignores[filepath.ToSlash(filepath.Dir(relPath))]
+		if !s.options.DisableGitignore && stack.ignored(relPath, true) {
+			return filepath.SkipDir
+		}
+	}
+
+	if !s.options.DisableGitignore {
+		base := relPath
+		if base == "." {
+			base = ""
+		}
+
+		list, err := readIgnoreList(fullPath, base)
+		if err != nil {
+			return err
+		}
+
+		if list != nil {
+			stack = append(stack[:len(stack):len(stack)], list)
+		}
+	}
+
+	ignores[relPath] = stack
+
+	return nil
+}
+
+func (s *Scanner) skipFile(relPath string, stack ignoreStack) bool {
+	if s.exclude.match(relPath) {
+		return true
+	}
+
+	if len(s.include) > 0 && !s.include.match(relPath) {
+		return true
+	}
+
+	return !s.options.DisableGitignore && stack.ignored(relPath, false)
+}
+
+// ScanContent classifies the content of a file with the path.
+// License files are classified as a whole and always produce a finding,
+// source files produce a finding only when their header matches a known text.
+func (s *Scanner) ScanContent(path string, content []byte) []Finding {
+	if isLicenseFile(path) {
+		text := string(content)
+
+		finding := s.classify(text)
+		finding.Path = path
+		finding.Kind = KindLicenseFile
+		finding.Region = Region{StartLine: 1, EndLine: countLines(text)}
+
+		return []Finding{finding}
+	}
+
+	style, ok := sourceCommentStyle(path)
+	if !ok {
+		return nil
+	}
+
+	header, ok := extractHeader(string(content), style)
+	if !ok {
+		return nil
+	}
+
+	finding := s.classify(header.text)
+	if len(finding.Matches) == 0 {
+		return nil
+	}
+
+	finding.Path = path
+	finding.Kind = KindSourceHeader
+	finding.Region = Region{StartLine: header.startLine, EndLine: header.endLine}
+
+	return []Finding{finding}
+}
+
+func (s *Scanner) classify(text string) Finding {
+	classification := s.matcher.Classify(text)
+
+	finding := Finding{
+		Matches: []compare.Match{},
+	}
+
+	for _, match := range classification.Candidates {
+		if match.Confidence > 0 && match.Confidence >= s.options.Threshold {
+			finding.Matches = append(finding.Matches, match)
+		}
+	}
+
+	if len(finding.Matches) > 0 {
+		finding.Decision = classification.Decision
+		finding.Ambiguous = classification.Ambiguous
+	}
+
+	return finding
+}
+
+func countLines(text string) int {
+	lines := 1
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' && i != len(text)-1 {
+			lines++
+		}
+	}
+
+	return lines
+}
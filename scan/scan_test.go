@@ -0,0 +1,126 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseMatcher(t *testing.T) *compare.TextMatcher {
+	texts, err := compare.ReadTexts("../testdata/licenses")
+	require.NoError(t, err)
+
+	return compare.NewTextMatcher(texts...)
+}
+
+func readLicense(t *testing.T, name string) string {
+	content, err := os.ReadFile(filepath.Join("../testdata/licenses", name+".txt"))
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func writeTree(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func commented(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = "// " + line
+	}
+
+	return strings.Join(lines, "\n") + "\n\npackage main\n"
+}
+
+func TestScanner_Scan(t *testing.T) {
+	root := writeTree(t, map[string]string{
+		".gitignore":                 "ignored/\n*.bak\n",
+		"LICENSE":                    readLicense(t, "MIT"),
+		"main.go":                    commented(readLicense(t, "ISC")),
+		"util.go":                    "package main\n",
+		"notes.bak/LICENSE":          readLicense(t, "MIT"),
+		"ignored/LICENSE":            readLicense(t, "MIT"),
+		"vendor/lib/COPYING":         readLicense(t, "BSD-2-Clause"),
+		"vendor/lib/.gitignore":      "/COPYING.old\n",
+		"vendor/lib/COPYING.old":     readLicense(t, "ISC"),
+		"third_party/LICENSE":        "Some proprietary terms nobody knows.",
+		"third_party/huge/LICENSE":   strings.Repeat(readLicense(t, "MIT"), 100),
+		"third_party/excluded/NOTES": "",
+	})
+
+	t.Run("default", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{Threshold: 0.5, MaxSize: 1 << 14})
+		require.NoError(t, err)
+
+		findings, err := scanner.Scan(root)
+		require.NoError(t, err)
+
+		type summary struct {
+			path, license string
+			kind          Kind
+		}
+
+		result := []summary{}
+		for _, finding := range findings {
+			best, _ := finding.Best()
+			result = append(result, summary{path: finding.Path, license: best.TextName, kind: finding.Kind})
+		}
+
+		assert.Equal(t, []summary{
+			{path: "LICENSE", license: "MIT", kind: KindLicenseFile},
+			{path: "main.go", license: "ISC", kind: KindSourceHeader},
+			{path: "third_party/LICENSE", license: "", kind: KindLicenseFile},
+			{path: "vendor/lib/COPYING", license: "BSD-2-Clause", kind: KindLicenseFile},
+		}, result)
+
+		assert.Equal(t, Region{StartLine: 1, EndLine: 15}, findings[1].Region)
+		assert.Equal(t, compare.DecisionNone, findings[2].Decision)
+	})
+
+	t.Run("include_exclude", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{
+			Include:          []string{"LICENSE", "COPYING*"},
+			Exclude:          []string{"vendor"},
+			DisableGitignore: true,
+		})
+		require.NoError(t, err)
+
+		findings, err := scanner.Scan(root)
+		require.NoError(t, err)
+
+		paths := []string{}
+		for _, finding := range findings {
+			paths = append(paths, finding.Path)
+		}
+
+		assert.Equal(t, []string{
+			"LICENSE",
+			"ignored/LICENSE",
+			"notes.bak/LICENSE",
+			"third_party/LICENSE",
+			"third_party/huge/LICENSE",
+		}, paths)
+	})
+
+	t.Run("missing_root", func(t *testing.T) {
+		scanner, err := New(licenseMatcher(t), Options{})
+		require.NoError(t, err)
+
+		_, err = scanner.Scan(filepath.Join(root, "missing"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,95 @@
+package compare
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// Segmentation defines how a cleaned up text is split in tokens.
+type Segmentation int
+
+const (
+	// SegmentSpaces splits the text by spaces, punctuation stays attached to words.
+	SegmentSpaces Segmentation = iota
+	// SegmentWords splits the text by Unicode word boundaries (UAX #29),
+	// so languages written without spaces get tokens too. Punctuation and spaces
+	// are dropped. Chinese and Japanese have no word boundaries, their runs of
+	// ideographs and kana become overlapping character bigrams, "东京都"
+	// gives "东京" and "京都", and a single character stays as is.
+	SegmentWords
+)
+
+// span is a half-open byte range of a text.
+type span struct {
+	start, end int
+}
+
+// segmentWords returns spans of words of the text by Unicode word boundaries
+// with runs of Chinese and Japanese characters cut in character bigrams.
+func segmentWords(text string) []span {
+	var (
+		spans []span
+		run   []int // offsets of characters of the current CJK run and the end of the last one
+	)
+
+	flush := func() {
+		switch {
+		case len(run) == 2:
+			spans = append(spans, span{start: run[0], end: run[1]})
+		case len(run) > 2:
+			for i := 0; i+2 < len(run); i++ {
+				spans = append(spans, span{start: run[i], end: run[i+2]})
+			}
+		}
+
+		run = run[:0]
+	}
+
+	state := -1
+	for offset, rest := 0, text; len(rest) > 0; {
+		var word string
+
+		word, rest, state = uniseg.FirstWordInString(rest, state)
+
+		switch first, _ := utf8.DecodeRuneInString(word); {
+		case isCJK(first):
+			if len(run) > 0 {
+				run = run[:len(run)-1]
+			}
+
+			for i := range word {
+				run = append(run, offset+i)
+			}
+			run = append(run, offset+len(word))
+		case isWordLike(word):
+			flush()
+			spans = append(spans, span{start: offset, end: offset + len(word)})
+		default:
+			flush()
+		}
+
+		offset += len(word)
+	}
+
+	flush()
+
+	return spans
+}
+
+// isCJK reports whether the character is written without spaces between words in Chinese or Japanese.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// isWordLike reports whether the segment contains a letter or a digit, unlike spaces and punctuation.
+func isWordLike(segment string) bool {
+	for _, r := range segment {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,106 @@
+package compare
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizer_Segmentation(t *testing.T) {
+	words := Tokenizer{Segmentation: SegmentWords}
+
+	type testcase struct {
+		input    string
+		expected []string
+	}
+
+	testcases := map[string]testcase{
+		"punctuation_dropped": {
+			input:    `Lorem ipsum, "dolor" sit-amet (c) 3.14 don't.`,
+			expected: []string{"lorem", "ipsum", "dolor", "sit", "amet", "c", "3.14", "don't"},
+		},
+		"chinese_bigrams": {
+			input:    "本软件按原样提供。",
+			expected: []string{"本软", "软件", "件按", "按原", "原样", "样提", "提供"},
+		},
+		"japanese_mixed_scripts": {
+			input:    "東京タワー MIT ライセンス",
+			expected: []string{"東京", "京タ", "タワ", "ワー", "mit", "ライ", "イセ", "セン", "ンス"},
+		},
+		"single_ideograph": {
+			input:    "a 我 b",
+			expected: []string{"a", "我", "b"},
+		},
+		"korean_words": {
+			input:    "소프트웨어를 제공합니다",
+			expected: []string{"소프트웨어를", "제공합니다"},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, words.Tokenize(tc.input))
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, []string{}, words.Tokenize(" \n "))
+	})
+
+	t.Run("chinese_texts_compared", func(t *testing.T) {
+		original := "本软件按原样提供，不提供任何明示或暗示的担保。"
+		modified := "本软件按原样提供，不提供任何担保。"
+
+		assert.Len(t, Tokenize(original), 1)
+		assert.Greater(t, len(words.Tokenize(original)), 10)
+
+		matcher := NewTextMatcherWithTokenizer(words, Text{Name: "original", Content: original})
+		assert.InDelta(t, 0.5, matcher.Match(modified)[0].Confidence, 0.2)
+	})
+}
+
+func TestTokenizer_StopWordsAndStem(t *testing.T) {
+	tokenizer := Tokenizer{
+		StopWords: StopWords("en"),
+		Stem: func(token string) string {
+			return strings.TrimSuffix(token, "s")
+		},
+	}
+
+	assert.Equal(t, []string{"permission", "copie", "software"},
+		tokenizer.Tokenize("Permission to the copies of the Software"))
+
+	assert.Equal(t, []Token{
+		{Text: "license", Start: 4, End: 12},
+		{Text: "grant", Start: 13, End: 19},
+	}, tokenizer.TokenizeWithOffsets("The licenses grants"))
+
+	german := Tokenizer{Segmentation: SegmentWords, StopWords: StopWords("de")}
+	assert.Equal(t, []string{"software", "ohne", "gewährleistung", "bereitgestellt"},
+		german.Tokenize("Die Software wird ohne Gewährleistung bereitgestellt."))
+}
+
+func TestStopWords(t *testing.T) {
+	for _, language := range []string{"en", "de", "es", "fr", "it", "nl", "pt", "ru", "uk"} {
+		words := StopWords(language)
+		assert.NotEmpty(t, words, language)
+
+		for word := range words {
+			assert.Equal(t, []string{word}, Tokenize(word), "%s: %s", language, word)
+		}
+	}
+
+	assert.Nil(t, StopWords("xx"))
+}
+
+func TestTokenizer_SegmentWordsOffsets(t *testing.T) {
+	text := "Hello, 世界和平!"
+
+	assert.Equal(t, []Token{
+		{Text: "hello", Start: 0, End: 5},
+		{Text: "世界", Start: 7, End: 13},
+		{Text: "界和", Start: 10, End: 16},
+		{Text: "和平", Start: 13, End: 19},
+	}, Tokenizer{Segmentation: SegmentWords}.TokenizeWithOffsets(text))
+}
@@ -0,0 +1,117 @@
+package server
+
+import "github.com/radikh/compare"
+
+// HealthResponse is the response of the health check.
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// ErrorResponse is the response of a failed request.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// CompareRequest is the request to compare two texts.
+type CompareRequest struct {
+	Text1 string `json:"text1"`
+	Text2 string `json:"text2"`
+	// Ordered makes the comparison take the order of the texts parts into account.
+	Ordered bool `json:"ordered"`
+}
+
+// CompareResponse is the similarity rate of the compared texts in range of 0 to 1.
+type CompareResponse struct {
+	Score float64 `json:"score"`
+}
+
+// Text is a text of a corpus.
+type Text struct {
+	Name      string   `json:"name"`
+	Content   string   `json:"content"`
+	Required  []string `json:"required,omitempty"`
+	Forbidden []string `json:"forbidden,omitempty"`
+}
+
+func newText(text compare.Text) Text {
+	return Text{
+		Name:      text.Name,
+		Content:   text.Content,
+		Required:  text.Required,
+		Forbidden: text.Forbidden,
+	}
+}
+
+func (t Text) text() compare.Text {
+	return compare.Text{
+		Name:      t.Name,
+		Content:   t.Content,
+		Required:  t.Required,
+		Forbidden: t.Forbidden,
+	}
+}
+
+// CorpusInfo describes a corpus.
+type CorpusInfo struct {
+	Name  string `json:"name"`
+	Texts int    `json:"texts"`
+}
+
+// CorporaResponse lists corpora ordered by name.
+type CorporaResponse struct {
+	Corpora []CorpusInfo `json:"corpora"`
+}
+
+// RemoveResponse is the number of texts removed from a corpus.
+type RemoveResponse struct {
+	Removed int `json:"removed"`
+}
+
+// MatchRequest is the request to match a text with a corpus.
+type MatchRequest struct {
+	Text string `json:"text"`
+	// Top limits the number of returned matches, zero means no limit.
+	Top int `json:"top"`
+	// MinConfidence filters out matches with lower confidence.
+	MinConfidence float64 `json:"min_confidence"`
+	// Weighted weights pairs of words with their inverse document frequency in the corpus.
+	Weighted bool `json:"weighted"`
+}
+
+// MatchResponse contains matches ordered by confidence descending
+// and the calibrated decision on the best of them.
+type MatchResponse struct {
+	Decision string  `json:"decision"`
+	Matches  []Match `json:"matches"`
+}
+
+// Match is a match of a corpus text.
+type Match struct {
+	Name       string  `json:"name"`
+	Confidence float64 `json:"confidence"`
+	Rules      []Rule  `json:"rules,omitempty"`
+}
+
+// Rule is a phrase rule which fired and demoted the match.
+type Rule struct {
+	Kind   string `json:"kind"`
+	Phrase string `json:"phrase"`
+}
+
+func newMatch(match compare.Match) Match {
+	converted := Match{
+		Name:       match.TextName,
+		Confidence: match.Confidence,
+	}
+
+	for _, rule := range match.Rules {
+		kind := "required"
+		if rule.Kind == compare.RuleForbidden {
+			kind = "forbidden"
+		}
+
+		converted.Rules = append(converted.Rules, Rule{Kind: kind, Phrase: rule.Phrase})
+	}
+
+	return converted
+}
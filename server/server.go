@@ -0,0 +1,386 @@
+// Package server exposes text comparison and matching over HTTP with JSON bodies.
+//
+// Endpoints:
+//
+//	GET    /healthz                          health check
+//	POST   /compare                          compare two texts
+//	GET    /corpora                          list named corpora
+//	DELETE /corpora/{corpus}                 delete a corpus
+//	POST   /corpora/{corpus}/match           match a text with the corpus texts
+//	POST   /corpora/{corpus}/texts           feed a text to the corpus, it is created when missing
+//	DELETE /corpora/{corpus}/texts/{text}    remove texts with the name from the corpus
+//	GET    /corpora/{corpus}/snapshot        save the corpus texts
+//	PUT    /corpora/{corpus}/snapshot        replace the corpus with the saved texts
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/radikh/compare"
+)
+
+// DefaultMaxBodySize is the request body size limit used when Options.MaxBodySize is zero.
+const DefaultMaxBodySize = 4 << 20
+
+// Options configures the server.
+type Options struct {
+	// MaxBodySize limits the size of request bodies in bytes,
+	// larger requests are rejected with 413 status.
+	MaxBodySize int64
+}
+
+// corpus is a named set of texts with the matcher built from them.
+// Texts are kept to save snapshots of the corpus.
+type corpus struct {
+	texts   []compare.Text
+	matcher *compare.TextMatcher
+}
+
+func newCorpus(texts []compare.Text) *corpus {
+	return &corpus{
+		texts:   texts,
+		matcher: compare.NewTextMatcher(texts...),
+	}
+}
+
+// Server is an http.Handler serving compare and match requests.
+// It is safe for concurrent use, matching runs in parallel
+// while changes of corpora are exclusive.
+type Server struct {
+	options Options
+
+	mu      sync.RWMutex
+	corpora map[string]*corpus
+}
+
+// New creates a server without corpora.
+func New(options Options) *Server {
+	if options.MaxBodySize == 0 {
+		options.MaxBodySize = DefaultMaxBodySize
+	}
+
+	return &Server{
+		options: options,
+		corpora: map[string]*corpus{},
+	}
+}
+
+// SetCorpus creates or replaces the named corpus with the texts.
+func (s *Server) SetCorpus(name string, texts []compare.Text) {
+	c := newCorpus(append([]compare.Text(nil), texts...))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.corpora[name] = c
+}
+
+// ServeHTTP routes the request to the endpoint handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.options.MaxBodySize)
+
+	segments, err := pathSegments(r.URL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	switch {
+	case len(segments) == 1 && segments[0] == "healthz":
+		s.route(w, r, map[string]http.HandlerFunc{http.MethodGet: s.health})
+	case len(segments) == 1 && segments[0] == "compare":
+		s.route(w, r, map[string]http.HandlerFunc{http.MethodPost: s.compare})
+	case len(segments) == 1 && segments[0] == "corpora":
+		s.route(w, r, map[string]http.HandlerFunc{http.MethodGet: s.listCorpora})
+	case len(segments) == 2 && segments[0] == "corpora":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { s.deleteCorpus(w, segments[1]) },
+		})
+	case len(segments) == 3 && segments[0] == "corpora" && segments[2] == "match":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodPost: func(w http.ResponseWriter, r *http.Request) { s.match(w, r, segments[1]) },
+		})
+	case len(segments) == 3 && segments[0] == "corpora" && segments[2] == "texts":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodPost: func(w http.ResponseWriter, r *http.Request) { s.feed(w, r, segments[1]) },
+		})
+	case len(segments) == 4 && segments[0] == "corpora" && segments[2] == "texts":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodDelete: func(w http.ResponseWriter, r *http.Request) { s.remove(w, segments[1], segments[3]) },
+		})
+	case len(segments) == 3 && segments[0] == "corpora" && segments[2] == "snapshot":
+		s.route(w, r, map[string]http.HandlerFunc{
+			http.MethodGet: func(w http.ResponseWriter, r *http.Request) { s.saveSnapshot(w, segments[1]) },
+			http.MethodPut: func(w http.ResponseWriter, r *http.Request) { s.loadSnapshot(w, r, segments[1]) },
+		})
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("no endpoint %s", r.URL.Path))
+	}
+}
+
+// route calls the handler of the request method.
+func (s *Server) route(w http.ResponseWriter, r *http.Request, handlers map[string]http.HandlerFunc) {
+	if handler, ok := handlers[r.Method]; ok {
+		handler(w, r)
+		return
+	}
+
+	methods := make([]string, 0, len(handlers))
+	for method := range handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+}
+
+// pathSegments splits the unescaped URL path, so names may contain escaped slashes.
+func pathSegments(u *url.URL) ([]string, error) {
+	escaped := strings.Trim(u.EscapedPath(), "/")
+	if escaped == "" {
+		return nil, nil
+	}
+
+	segments := strings.Split(escaped, "/")
+
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, err
+		}
+
+		if unescaped == "" {
+			return nil, errors.New("empty path segment")
+		}
+
+		segments[i] = unescaped
+	}
+
+	return segments, nil
+}
+
+func (s *Server) health(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+func (s *Server) compare(w http.ResponseWriter, r *http.Request) {
+	var request CompareRequest
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	response := CompareResponse{}
+	if request.Ordered {
+		response.Score = compare.CompareTextsOrdered(request.Text1, request.Text2)
+	} else {
+		response.Score = compare.CompareTexts(request.Text1, request.Text2)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) listCorpora(w http.ResponseWriter, _ *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	response := CorporaResponse{Corpora: make([]CorpusInfo, 0, len(s.corpora))}
+	for name, c := range s.corpora {
+		response.Corpora = append(response.Corpora, CorpusInfo{Name: name, Texts: len(c.texts)})
+	}
+
+	sort.Slice(response.Corpora, func(i, j int) bool {
+		return response.Corpora[i].Name < response.Corpora[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) deleteCorpus(w http.ResponseWriter, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.corpora[name]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no corpus %q", name))
+		return
+	}
+
+	delete(s.corpora, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) match(w http.ResponseWriter, r *http.Request, name string) {
+	var request MatchRequest
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	if request.Top < 0 {
+		writeError(w, http.StatusBadRequest, errors.New("top must not be negative"))
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.corpora[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no corpus %q", name))
+		return
+	}
+
+	var matches []compare.Match
+	if request.Weighted {
+		matches = c.matcher.MatchWeighted(request.Text)
+	} else {
+		matches = c.matcher.Match(request.Text)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	response := MatchResponse{Matches: []Match{}}
+
+	for _, match := range matches {
+		if match.Confidence < request.MinConfidence {
+			break
+		}
+
+		if request.Top > 0 && len(response.Matches) == request.Top {
+			break
+		}
+
+		response.Matches = append(response.Matches, newMatch(match))
+	}
+
+	if len(matches) > 0 {
+		response.Decision = c.matcher.Calibration().Decide(matches[0].Confidence).String()
+	} else {
+		response.Decision = compare.DecisionNone.String()
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) feed(w http.ResponseWriter, r *http.Request, name string) {
+	var text Text
+	if !readJSON(w, r, &text) {
+		return
+	}
+
+	if text.Name == "" {
+		writeError(w, http.StatusBadRequest, errors.New("text name is required"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.corpora[name]
+	if !ok {
+		c = newCorpus(nil)
+		s.corpora[name] = c
+	}
+
+	c.texts = append(c.texts, text.text())
+	c.matcher.FeedText(text.text())
+
+	writeJSON(w, http.StatusCreated, CorpusInfo{Name: name, Texts: len(c.texts)})
+}
+
+func (s *Server) remove(w http.ResponseWriter, name, textName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.corpora[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no corpus %q", name))
+		return
+	}
+
+	removed := c.matcher.Remove(textName)
+	if removed == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no text %q in corpus %q", textName, name))
+		return
+	}
+
+	kept := c.texts[:0]
+	for _, text := range c.texts {
+		if text.Name != textName {
+			kept = append(kept, text)
+		}
+	}
+	c.texts = kept
+
+	writeJSON(w, http.StatusOK, RemoveResponse{Removed: removed})
+}
+
+func (s *Server) saveSnapshot(w http.ResponseWriter, name string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	c, ok := s.corpora[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no corpus %q", name))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSnapshot(c.texts))
+}
+
+func (s *Server) loadSnapshot(w http.ResponseWriter, r *http.Request, name string) {
+	var snapshot Snapshot
+	if !readJSON(w, r, &snapshot) {
+		return
+	}
+
+	texts, err := snapshot.texts()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	s.SetCorpus(name, texts)
+
+	writeJSON(w, http.StatusOK, CorpusInfo{Name: name, Texts: len(texts)})
+}
+
+// readJSON decodes the request body, it writes an error response and returns false on failure.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		status := http.StatusBadRequest
+
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		writeError(w, status, fmt.Errorf("decode request: %w", err))
+
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,261 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func licenseTexts(t *testing.T) []compare.Text {
+	texts, err := compare.ReadTexts("../testdata/licenses")
+	require.NoError(t, err)
+
+	return texts
+}
+
+func readLicense(t *testing.T, name string) string {
+	content, err := os.ReadFile("../testdata/licenses/" + name + ".txt")
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+// do sends the request to the handler and decodes the JSON response into v when it's not nil.
+func do(t *testing.T, handler http.Handler, method, target string, body any, v any) int {
+	var reader *bytes.Reader
+
+	switch body := body.(type) {
+	case nil:
+		reader = bytes.NewReader(nil)
+	case string:
+		reader = bytes.NewReader([]byte(body))
+	default:
+		encoded, err := json.Marshal(body)
+		require.NoError(t, err)
+
+		reader = bytes.NewReader(encoded)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, target, reader))
+
+	if v != nil {
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), v), recorder.Body.String())
+	}
+
+	return recorder.Code
+}
+
+func TestServer_Health(t *testing.T) {
+	var response HealthResponse
+
+	code := do(t, New(Options{}), http.MethodGet, "/healthz", nil, &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, HealthResponse{Status: "ok"}, response)
+}
+
+func TestServer_Compare(t *testing.T) {
+	s := New(Options{})
+
+	request := CompareRequest{
+		Text1: "one two three four",
+		Text2: "three four one two",
+	}
+
+	var response CompareResponse
+
+	code := do(t, s, http.MethodPost, "/compare", request, &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, compare.CompareTexts(request.Text1, request.Text2), response.Score)
+
+	request.Ordered = true
+
+	code = do(t, s, http.MethodPost, "/compare", request, &response)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, compare.CompareTextsOrdered(request.Text1, request.Text2), response.Score)
+}
+
+func TestServer_Match(t *testing.T) {
+	s := New(Options{})
+	s.SetCorpus("licenses", licenseTexts(t))
+
+	t.Run("all", func(t *testing.T) {
+		var response MatchResponse
+
+		code := do(t, s, http.MethodPost, "/corpora/licenses/match", MatchRequest{Text: readLicense(t, "MIT")}, &response)
+		require.Equal(t, http.StatusOK, code)
+
+		assert.Equal(t, "exact", response.Decision)
+		require.Len(t, response.Matches, 3)
+		assert.Equal(t, "MIT", response.Matches[0].Name)
+		assert.InDelta(t, 1, response.Matches[0].Confidence, 1e-9)
+		assert.GreaterOrEqual(t, response.Matches[1].Confidence, response.Matches[2].Confidence)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		var response MatchResponse
+
+		request := MatchRequest{Text: readLicense(t, "ISC"), Top: 2, MinConfidence: 0.5, Weighted: true}
+
+		code := do(t, s, http.MethodPost, "/corpora/licenses/match", request, &response)
+		require.Equal(t, http.StatusOK, code)
+
+		require.Len(t, response.Matches, 1)
+		assert.Equal(t, "ISC", response.Matches[0].Name)
+	})
+
+	t.Run("unknown_corpus", func(t *testing.T) {
+		var response ErrorResponse
+
+		code := do(t, s, http.MethodPost, "/corpora/missing/match", MatchRequest{Text: "text"}, &response)
+		assert.Equal(t, http.StatusNotFound, code)
+		assert.Equal(t, `no corpus "missing"`, response.Error)
+	})
+
+	t.Run("bad_request", func(t *testing.T) {
+		var response ErrorResponse
+
+		code := do(t, s, http.MethodPost, "/corpora/licenses/match", `{"text": "a", "unknown": 1}`, &response)
+		assert.Equal(t, http.StatusBadRequest, code)
+
+		code = do(t, s, http.MethodPost, "/corpora/licenses/match", MatchRequest{Top: -1}, &response)
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestServer_FeedRemove(t *testing.T) {
+	s := New(Options{})
+
+	var info CorpusInfo
+
+	code := do(t, s, http.MethodPost, "/corpora/custom/texts", Text{Name: "MIT", Content: readLicense(t, "MIT")}, &info)
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, CorpusInfo{Name: "custom", Texts: 1}, info)
+
+	code = do(t, s, http.MethodPost, "/corpora/custom/texts", Text{
+		Name:      "ISC",
+		Content:   readLicense(t, "ISC"),
+		Forbidden: []string{"without restriction, including"},
+	}, &info)
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, CorpusInfo{Name: "custom", Texts: 2}, info)
+
+	var corpora CorporaResponse
+
+	code = do(t, s, http.MethodGet, "/corpora", nil, &corpora)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []CorpusInfo{{Name: "custom", Texts: 2}}, corpora.Corpora)
+
+	var matches MatchResponse
+
+	code = do(t, s, http.MethodPost, "/corpora/custom/match", MatchRequest{Text: readLicense(t, "MIT"), Top: 1}, &matches)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "MIT", matches.Matches[0].Name)
+
+	code = do(t, s, http.MethodPost, "/corpora/custom/match", MatchRequest{Text: "permission " + readLicense(t, "MIT")}, &matches)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []Rule{{Kind: "forbidden", Phrase: "without restriction, including"}}, matches.Matches[1].Rules)
+
+	var removed RemoveResponse
+
+	code = do(t, s, http.MethodDelete, "/corpora/custom/texts/MIT", nil, &removed)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, RemoveResponse{Removed: 1}, removed)
+
+	code = do(t, s, http.MethodDelete, "/corpora/custom/texts/MIT", nil, &ErrorResponse{})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code = do(t, s, http.MethodPost, "/corpora/custom/texts", Text{Content: "no name"}, &ErrorResponse{})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code = do(t, s, http.MethodDelete, "/corpora/custom", nil, nil)
+	assert.Equal(t, http.StatusNoContent, code)
+
+	code = do(t, s, http.MethodDelete, "/corpora/custom", nil, &ErrorResponse{})
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer_Snapshot(t *testing.T) {
+	s := New(Options{})
+	s.SetCorpus("licenses", licenseTexts(t))
+
+	var snapshot Snapshot
+
+	code := do(t, s, http.MethodGet, "/corpora/licenses/snapshot", nil, &snapshot)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, SnapshotVersion, snapshot.Version)
+	assert.Len(t, snapshot.Texts, 3)
+
+	var info CorpusInfo
+
+	code = do(t, s, http.MethodPut, "/corpora/copy/snapshot", snapshot, &info)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, CorpusInfo{Name: "copy", Texts: 3}, info)
+
+	code = do(t, s, http.MethodPut, "/corpora/copy/snapshot", Snapshot{Version: 2}, &ErrorResponse{})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	t.Run("save_load", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, s.Save(&buf))
+
+		loaded := New(Options{})
+		require.NoError(t, loaded.Load(&buf))
+
+		var corpora CorporaResponse
+
+		do(t, loaded, http.MethodGet, "/corpora", nil, &corpora)
+		assert.Equal(t, []CorpusInfo{{Name: "copy", Texts: 3}, {Name: "licenses", Texts: 3}}, corpora.Corpora)
+
+		assert.Error(t, loaded.Load(strings.NewReader(`{"broken": {"version": 0}}`)))
+	})
+}
+
+func TestServer_Routing(t *testing.T) {
+	s := New(Options{MaxBodySize: 64})
+
+	t.Run("not_found", func(t *testing.T) {
+		for _, target := range []string{"/", "/unknown", "/corpora/a/b"} {
+			code := do(t, s, http.MethodGet, target, nil, &ErrorResponse{})
+			assert.Equal(t, http.StatusNotFound, code, target)
+		}
+
+		code := do(t, s, http.MethodGet, "/corpora//match", nil, &ErrorResponse{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("method_not_allowed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		s.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/corpora/a/snapshot", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+		assert.Equal(t, "GET, PUT", recorder.Header().Get("Allow"))
+	})
+
+	t.Run("escaped_names", func(t *testing.T) {
+		var info CorpusInfo
+
+		code := do(t, s, http.MethodPost, "/corpora/a%2Fb/texts", Text{Name: "x/y", Content: "text"}, &info)
+		assert.Equal(t, http.StatusCreated, code)
+		assert.Equal(t, "a/b", info.Name)
+
+		code = do(t, s, http.MethodDelete, "/corpora/a%2Fb/texts/x%2Fy", nil, &RemoveResponse{})
+		assert.Equal(t, http.StatusOK, code)
+	})
+
+	t.Run("body_limit", func(t *testing.T) {
+		var response ErrorResponse
+
+		code := do(t, s, http.MethodPost, "/compare", CompareRequest{Text1: strings.Repeat("word ", 100)}, &response)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+	})
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/radikh/compare"
+)
+
+// SnapshotVersion is the version of the snapshot format.
+const SnapshotVersion = 1
+
+// Snapshot is a saved corpus, loading it recreates the corpus.
+type Snapshot struct {
+	Version int    `json:"version"`
+	Texts   []Text `json:"texts"`
+}
+
+func newSnapshot(texts []compare.Text) Snapshot {
+	snapshot := Snapshot{
+		Version: SnapshotVersion,
+		Texts:   make([]Text, 0, len(texts)),
+	}
+
+	for _, text := range texts {
+		snapshot.Texts = append(snapshot.Texts, newText(text))
+	}
+
+	return snapshot
+}
+
+func (s Snapshot) texts() ([]compare.Text, error) {
+	if s.Version != SnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+
+	texts := make([]compare.Text, 0, len(s.Texts))
+	for i, text := range s.Texts {
+		if text.Name == "" {
+			return nil, fmt.Errorf("text %d has no name", i)
+		}
+
+		texts = append(texts, text.text())
+	}
+
+	return texts, nil
+}
+
+// Save writes snapshots of all the corpora as a JSON object keyed by corpus names.
+func (s *Server) Save(w io.Writer) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := make(map[string]Snapshot, len(s.corpora))
+	for name, c := range s.corpora {
+		snapshots[name] = newSnapshot(c.texts)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(snapshots)
+}
+
+// Load reads corpora saved by Save, they replace existing corpora with the same names.
+func (s *Server) Load(r io.Reader) error {
+	var snapshots map[string]Snapshot
+	if err := json.NewDecoder(r).Decode(&snapshots); err != nil {
+		return fmt.Errorf("decode snapshot: %w", err)
+	}
+
+	corpora := make(map[string]*corpus, len(snapshots))
+	for name, snapshot := range snapshots {
+		texts, err := snapshot.texts()
+		if err != nil {
+			return fmt.Errorf("corpus %q: %w", name, err)
+		}
+
+		corpora[name] = newCorpus(texts)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, c := range corpora {
+		s.corpora[name] = c
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package spdx
+
+import (
+	"strings"
+
+	"github.com/radikh/compare"
+)
+
+// FromMatches builds the license expression of a package from matches found in its files.
+// Matches below the threshold are skipped, licenses found in different files
+// all apply, so they're joined with AND. Text names which are not valid expressions
+// of known identifiers become LicenseRef references.
+// It returns nil when no match passes the threshold.
+func FromMatches(matches []compare.Match, threshold float64) Expression {
+	terms := []Expression{}
+
+	for _, match := range matches {
+		if match.Confidence < threshold || match.Confidence == 0 {
+			continue
+		}
+
+		expression, err := Parse(match.TextName)
+		if err != nil || Validate(expression) != nil {
+			expression = License{ID: licenseRef(match.TextName)}
+		}
+
+		terms = append(terms, Normalize(expression))
+	}
+
+	if len(terms) == 0 {
+		return nil
+	}
+
+	return Simplify(And{Terms: terms})
+}
+
+// licenseRef converts the name to a user defined license reference.
+func licenseRef(name string) string {
+	if isUserDefined(name) {
+		return name
+	}
+
+	ref := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+
+	return "LicenseRef-" + ref
+}
@@ -0,0 +1,42 @@
+package spdx
+
+import (
+	"testing"
+
+	"github.com/radikh/compare"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMatches(t *testing.T) {
+	t.Run("licenses_of_files", func(t *testing.T) {
+		matches := []compare.Match{
+			{TextName: "MIT", Confidence: 0.98},
+			{TextName: "ISC", Confidence: 0.2},
+			{TextName: "apache-2.0", Confidence: 0.9},
+			{TextName: "MIT", Confidence: 0.95},
+		}
+
+		expression := FromMatches(matches, 0.5)
+
+		assert.Equal(t, "Apache-2.0 AND MIT", expression.String())
+	})
+
+	t.Run("expression_names", func(t *testing.T) {
+		matches := []compare.Match{
+			{TextName: "GPL-2.0+ WITH Classpath-exception-2.0", Confidence: 1},
+			{TextName: "Proprietary Terms", Confidence: 0.7},
+		}
+
+		expression := FromMatches(matches, 0.5)
+
+		assert.Equal(t, "GPL-2.0-or-later WITH Classpath-exception-2.0 AND LicenseRef-Proprietary-Terms", expression.String())
+		assert.NoError(t, Validate(expression))
+	})
+
+	t.Run("nothing_matched", func(t *testing.T) {
+		matches := []compare.Match{{TextName: "MIT", Confidence: 0.1}, {TextName: "ISC"}}
+
+		assert.Nil(t, FromMatches(matches[1:], 0))
+		assert.Nil(t, FromMatches(matches, 0.5))
+	})
+}
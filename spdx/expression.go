@@ -0,0 +1,84 @@
+// Package spdx works with SPDX license expressions like "MIT OR Apache-2.0"
+// or "GPL-2.0-or-later WITH Classpath-exception-2.0".
+package spdx
+
+import (
+	"strings"
+)
+
+// Expression is a node of a parsed license expression.
+type Expression interface {
+	// String renders the expression back to text.
+	String() string
+	expression()
+}
+
+// License is a single license identifier, OrLater is set for the "+" suffix.
+type License struct {
+	ID      string
+	OrLater bool
+}
+
+// With is a license with an exception.
+type With struct {
+	License   License
+	Exception string
+}
+
+// And is a conjunction of expressions, all of them apply.
+type And struct {
+	Terms []Expression
+}
+
+// Or is a disjunction of expressions, any of them may be chosen.
+type Or struct {
+	Terms []Expression
+}
+
+func (License) expression() {}
+func (With) expression()    {}
+func (And) expression()     {}
+func (Or) expression()      {}
+
+// String renders the license identifier.
+func (l License) String() string {
+	if l.OrLater {
+		return l.ID + "+"
+	}
+
+	return l.ID
+}
+
+// String renders the license with the exception.
+func (w With) String() string {
+	return w.License.String() + " WITH " + w.Exception
+}
+
+// String renders the conjunction, disjunctions in it are parenthesized.
+func (a And) String() string {
+	return joinTerms(a.Terms, " AND ", func(e Expression) bool {
+		_, ok := e.(Or)
+		return ok
+	})
+}
+
+// String renders the disjunction.
+func (o Or) String() string {
+	return joinTerms(o.Terms, " OR ", func(e Expression) bool {
+		return false
+	})
+}
+
+func joinTerms(terms []Expression, operator string, parenthesize func(Expression) bool) string {
+	parts := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		if parenthesize(term) {
+			parts = append(parts, "("+term.String()+")")
+		} else {
+			parts = append(parts, term.String())
+		}
+	}
+
+	return strings.Join(parts, operator)
+}
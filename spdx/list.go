@@ -0,0 +1,83 @@
+package spdx
+
+import (
+	_ "embed" // embedding of the license lists
+	"strings"
+	"sync"
+)
+
+// ListVersion is the version of the SPDX License List the bundled lists are taken from.
+const ListVersion = "3.25.0"
+
+// The bundled lists contain all license and exception identifiers of the SPDX License List
+// of ListVersion, one per line. Deprecated identifiers like GPL-2.0 are included
+// as they're valid in expressions.
+var (
+	//go:embed licenses.txt
+	licensesList string
+	//go:embed exceptions.txt
+	exceptionsList string
+
+	indexOnce      sync.Once
+	licensesIndex  map[string]string
+	exceptionIndex map[string]string
+)
+
+// index returns canonical identifiers of licenses and exceptions by their lower case form.
+func index() (licenses, exceptions map[string]string) {
+	indexOnce.Do(func() {
+		licensesIndex = readList(licensesList)
+		exceptionIndex = readList(exceptionsList)
+	})
+
+	return licensesIndex, exceptionIndex
+}
+
+func readList(list string) map[string]string {
+	result := map[string]string{}
+
+	for _, id := range strings.Fields(list) {
+		result[strings.ToLower(id)] = id
+	}
+
+	return result
+}
+
+// LookupLicense returns the canonical form of the license identifier from the bundled list.
+func LookupLicense(id string) (string, bool) {
+	licenses, _ := index()
+
+	canonical, ok := licenses[strings.ToLower(id)]
+
+	return canonical, ok
+}
+
+// LookupException returns the canonical form of the exception identifier from the bundled list.
+func LookupException(id string) (string, bool) {
+	_, exceptions := index()
+
+	canonical, ok := exceptions[strings.ToLower(id)]
+
+	return canonical, ok
+}
+
+// isUserDefined reports whether the identifier is a user defined reference
+// like LicenseRef-proprietary or DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2.
+func isUserDefined(id string) bool {
+	lower := strings.ToLower(id)
+
+	return strings.HasPrefix(lower, "licenseref-") ||
+		strings.HasPrefix(lower, "documentref-") && strings.Contains(lower, ":licenseref-")
+}
+
+// deprecatedGNU returns the current identifiers for deprecated GNU license
+// identifiers without the -only or -or-later suffix.
+func deprecatedGNU(id string) (only, orLater string, ok bool) {
+	switch id {
+	case "GPL-1.0", "GPL-2.0", "GPL-3.0", "LGPL-2.0", "LGPL-2.1", "LGPL-3.0",
+		"AGPL-1.0", "AGPL-3.0", "GFDL-1.1", "GFDL-1.2", "GFDL-1.3":
+		return id + "-only", id + "-or-later", true
+	default:
+		return "", "", false
+	}
+}
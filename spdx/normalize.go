@@ -0,0 +1,207 @@
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnknown is returned by Validate for identifiers absent in the bundled lists.
+var ErrUnknown = errors.New("spdx: unknown identifier")
+
+// Validate checks that all license and exception identifiers of the expression
+// are in the bundled SPDX lists or are user defined LicenseRef references.
+func Validate(expression Expression) error {
+	unknown := []string{}
+
+	walk(expression, func(e Expression) {
+		switch e := e.(type) {
+		case License:
+			if _, ok := LookupLicense(e.ID); !ok && !isUserDefined(e.ID) {
+				unknown = append(unknown, e.ID)
+			}
+		case With:
+			if _, ok := LookupException(e.Exception); !ok {
+				unknown = append(unknown, e.Exception)
+			}
+		}
+	})
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknown, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// walk calls the function for each node of the expression, including licenses of With nodes.
+func walk(expression Expression, fn func(Expression)) {
+	fn(expression)
+
+	switch e := expression.(type) {
+	case With:
+		fn(e.License)
+	case And:
+		for _, term := range e.Terms {
+			walk(term, fn)
+		}
+	case Or:
+		for _, term := range e.Terms {
+			walk(term, fn)
+		}
+	}
+}
+
+// Normalize replaces identifiers with their canonical form from the bundled lists
+// and deprecated GNU identifiers with current ones: GPL-2.0 becomes GPL-2.0-only
+// and GPL-2.0+ becomes GPL-2.0-or-later. Unknown identifiers are kept as is.
+func Normalize(expression Expression) Expression {
+	switch e := expression.(type) {
+	case License:
+		return normalizeLicense(e)
+	case With:
+		exception, ok := LookupException(e.Exception)
+		if !ok {
+			exception = e.Exception
+		}
+
+		return With{License: normalizeLicense(e.License), Exception: exception}
+	case And:
+		return And{Terms: mapTerms(e.Terms, Normalize)}
+	case Or:
+		return Or{Terms: mapTerms(e.Terms, Normalize)}
+	default:
+		return expression
+	}
+}
+
+func normalizeLicense(license License) License {
+	id, ok := LookupLicense(license.ID)
+	if !ok {
+		return license
+	}
+
+	if only, orLater, ok := deprecatedGNU(id); ok {
+		if license.OrLater {
+			return License{ID: orLater}
+		}
+
+		return License{ID: only}
+	}
+
+	if base := strings.TrimSuffix(id, "-only"); license.OrLater && base != id {
+		if orLater, ok := LookupLicense(base + "-or-later"); ok {
+			return License{ID: orLater}
+		}
+	}
+
+	return License{ID: id, OrLater: license.OrLater}
+}
+
+func mapTerms(terms []Expression, fn func(Expression) Expression) []Expression {
+	result := make([]Expression, 0, len(terms))
+
+	for _, term := range terms {
+		result = append(result, fn(term))
+	}
+
+	return result
+}
+
+// Simplify flattens nested operators, removes duplicate terms, applies absorption
+// like "MIT AND (MIT OR ISC)" to "MIT" and orders terms, so equivalent expressions
+// written differently render the same.
+func Simplify(expression Expression) Expression {
+	switch e := expression.(type) {
+	case And:
+		return simplifyTerms(e.Terms, true)
+	case Or:
+		return simplifyTerms(e.Terms, false)
+	default:
+		return expression
+	}
+}
+
+func simplifyTerms(terms []Expression, conjunction bool) Expression {
+	flat := []Expression{}
+
+	for _, term := range mapTerms(terms, Simplify) {
+		switch t := term.(type) {
+		case And:
+			if conjunction {
+				flat = append(flat, t.Terms...)
+				continue
+			}
+		case Or:
+			if !conjunction {
+				flat = append(flat, t.Terms...)
+				continue
+			}
+		}
+
+		flat = append(flat, term)
+	}
+
+	unique := map[string]Expression{}
+	for _, term := range flat {
+		unique[term.String()] = term
+	}
+
+	result := []Expression{}
+
+	for key, term := range unique {
+		if !absorbed(term, unique, key) {
+			result = append(result, term)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return strings.ToLower(result[i].String()) < strings.ToLower(result[j].String())
+	})
+
+	switch {
+	case len(result) == 1:
+		return result[0]
+	case conjunction:
+		return And{Terms: result}
+	default:
+		return Or{Terms: result}
+	}
+}
+
+// absorbed reports whether the term of an operator contains one of its siblings
+// as a term of the opposite operator and can be dropped.
+func absorbed(term Expression, siblings map[string]Expression, key string) bool {
+	var inner []Expression
+
+	switch t := term.(type) {
+	case And:
+		inner = t.Terms
+	case Or:
+		inner = t.Terms
+	default:
+		return false
+	}
+
+	for _, t := range inner {
+		if sibling := t.String(); sibling != key {
+			if _, ok := siblings[sibling]; ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Canonical parses the expression and renders it in the canonical form:
+// normalized identifiers, simplified structure and upper case operators.
+func Canonical(text string) (string, error) {
+	expression, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	return Simplify(Normalize(expression)).String(), nil
+}
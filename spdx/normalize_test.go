@@ -0,0 +1,81 @@
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{
+		"MIT",
+		"GPL-2.0-or-later WITH Classpath-exception-2.0",
+		"GPL-2.0+",
+		"LicenseRef-proprietary OR Apache-2.0",
+		"DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2",
+	}
+
+	for _, text := range valid {
+		expression, err := Parse(text)
+		require.NoError(t, err)
+
+		assert.NoError(t, Validate(expression), text)
+	}
+
+	expression, err := Parse("MIT AND (Foo-1.0 OR GPL-2.0-only WITH Bar-exception)")
+	require.NoError(t, err)
+
+	err = Validate(expression)
+	assert.ErrorIs(t, err, ErrUnknown)
+	assert.EqualError(t, err, "spdx: unknown identifier: Foo-1.0, Bar-exception")
+}
+
+func TestLookup(t *testing.T) {
+	id, ok := LookupLicense("apache-2.0")
+	assert.True(t, ok)
+	assert.Equal(t, "Apache-2.0", id)
+
+	id, ok = LookupException("CLASSPATH-EXCEPTION-2.0")
+	assert.True(t, ok)
+	assert.Equal(t, "Classpath-exception-2.0", id)
+
+	_, ok = LookupLicense("Classpath-exception-2.0")
+	assert.False(t, ok)
+
+	for _, id := range []string{"Elastic-2.0", "Unicode-3.0", "BlueOak-1.0.0", "GPL-2.0+"} {
+		canonical, ok := LookupLicense(id)
+		assert.True(t, ok, id)
+		assert.Equal(t, id, canonical)
+	}
+
+	assert.NoError(t, Validate(License{ID: "Elastic-2.0"}))
+}
+
+func TestCanonical(t *testing.T) {
+	testcases := map[string]string{
+		"mit":                                  "MIT",
+		"GPL-2.0":                              "GPL-2.0-only",
+		"gpl-2.0+":                             "GPL-2.0-or-later",
+		"LGPL-2.1-only+":                       "LGPL-2.1-or-later",
+		"Apache-2.0+":                          "Apache-2.0+",
+		"gpl-2.0 with classpath-exception-2.0": "GPL-2.0-only WITH Classpath-exception-2.0",
+		"MIT or Apache-2.0":                    "Apache-2.0 OR MIT",
+		"ISC AND (MIT AND BSD-2-Clause)":       "BSD-2-Clause AND ISC AND MIT",
+		"MIT OR MIT OR mit":                    "MIT",
+		"MIT AND (MIT OR ISC)":                 "MIT",
+		"ISC OR (ISC AND MIT)":                 "ISC",
+		"(MIT OR ISC) AND BSD-2-Clause":        "BSD-2-Clause AND (ISC OR MIT)",
+		"LicenseRef-foo AND Unknown-1.0":       "LicenseRef-foo AND Unknown-1.0",
+	}
+
+	for text, expected := range testcases {
+		result, err := Canonical(text)
+
+		assert.NoError(t, err, text)
+		assert.Equal(t, expected, result, text)
+	}
+
+	_, err := Canonical("MIT OR")
+	assert.ErrorIs(t, err, ErrSyntax)
+}
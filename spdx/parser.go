@@ -0,0 +1,183 @@
+package spdx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSyntax is returned for malformed expressions.
+var ErrSyntax = errors.New("spdx: syntax error")
+
+// Parse parses a license expression.
+// Operators are case insensitive, WITH binds tighter than AND which binds tighter than OR.
+func Parse(text string) (Expression, error) {
+	p := &parser{tokens: tokenize(text)}
+
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty expression", ErrSyntax)
+	}
+
+	expression, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrSyntax, p.tokens[p.pos])
+	}
+
+	return expression, nil
+}
+
+func tokenize(text string) []string {
+	text = strings.ReplaceAll(text, "(", " ( ")
+	text = strings.ReplaceAll(text, ")", " ) ")
+
+	return strings.Fields(text)
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *parser) isOperator(operator string) bool {
+	return strings.EqualFold(p.peek(), operator)
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	terms := []Expression{}
+
+	for {
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+
+		if !p.isOperator("OR") {
+			break
+		}
+		p.pos++
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+
+	return Or{Terms: terms}, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	terms := []Expression{}
+
+	for {
+		term, err := p.parseWith()
+		if err != nil {
+			return nil, err
+		}
+
+		terms = append(terms, term)
+
+		if !p.isOperator("AND") {
+			break
+		}
+		p.pos++
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+
+	return And{Terms: terms}, nil
+}
+
+func (p *parser) parseWith() (Expression, error) {
+	if p.peek() == "(" {
+		p.pos++
+
+		expression, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrSyntax)
+		}
+		p.pos++
+
+		return expression, nil
+	}
+
+	license, err := p.parseLicense()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.isOperator("WITH") {
+		return license, nil
+	}
+	p.pos++
+
+	exception := p.peek()
+	if !isIdentifier(exception) {
+		return nil, fmt.Errorf("%w: expected exception after WITH", ErrSyntax)
+	}
+	p.pos++
+
+	return With{License: license, Exception: exception}, nil
+}
+
+func (p *parser) parseLicense() (License, error) {
+	token := p.peek()
+
+	if token == "" {
+		return License{}, fmt.Errorf("%w: unexpected end of expression", ErrSyntax)
+	}
+
+	license := License{ID: token}
+	if strings.HasSuffix(token, "+") {
+		license = License{ID: strings.TrimSuffix(token, "+"), OrLater: true}
+	}
+
+	if !isIdentifier(license.ID) {
+		return License{}, fmt.Errorf("%w: unexpected %q", ErrSyntax, token)
+	}
+	p.pos++
+
+	return license, nil
+}
+
+// isIdentifier reports whether the token is a valid license or exception identifier
+// which is not an operator.
+func isIdentifier(token string) bool {
+	if token == "" {
+		return false
+	}
+
+	for _, operator := range []string{"AND", "OR", "WITH"} {
+		if strings.EqualFold(token, operator) {
+			return false
+		}
+	}
+
+	for _, r := range token {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package spdx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	testcases := map[string]struct {
+		text     string
+		expected Expression
+		rendered string
+	}{
+		"single": {
+			text:     "MIT",
+			expected: License{ID: "MIT"},
+			rendered: "MIT",
+		},
+		"or_later": {
+			text:     "GPL-2.0+",
+			expected: License{ID: "GPL-2.0", OrLater: true},
+			rendered: "GPL-2.0+",
+		},
+		"precedence": {
+			text: "MIT or Apache-2.0 AND GPL-2.0-or-later with Classpath-exception-2.0",
+			expected: Or{Terms: []Expression{
+				License{ID: "MIT"},
+				And{Terms: []Expression{
+					License{ID: "Apache-2.0"},
+					With{License: License{ID: "GPL-2.0-or-later"}, Exception: "Classpath-exception-2.0"},
+				}},
+			}},
+			rendered: "MIT OR Apache-2.0 AND GPL-2.0-or-later WITH Classpath-exception-2.0",
+		},
+		"parentheses": {
+			text: "(MIT OR ISC) AND BSD-2-Clause",
+			expected: And{Terms: []Expression{
+				Or{Terms: []Expression{License{ID: "MIT"}, License{ID: "ISC"}}},
+				License{ID: "BSD-2-Clause"},
+			}},
+			rendered: "(MIT OR ISC) AND BSD-2-Clause",
+		},
+		"license_ref": {
+			text:     "LicenseRef-proprietary",
+			expected: License{ID: "LicenseRef-proprietary"},
+			rendered: "LicenseRef-proprietary",
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			result, err := Parse(tc.text)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+			assert.Equal(t, tc.rendered, result.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"MIT OR",
+		"AND MIT",
+		"(MIT OR ISC",
+		"MIT ISC",
+		"MIT WITH",
+		"MIT WITH OR",
+		"MIT)",
+		"M!T",
+	} {
+		_, err := Parse(text)
+
+		assert.ErrorIs(t, err, ErrSyntax, text)
+	}
+}
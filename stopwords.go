@@ -0,0 +1,48 @@
+package compare
+
+import "strings"
+
+// StopWords returns a set of common words of the language, which carry little
+// meaning for matching, for the StopWords field of the Tokenizer.
+// The language is an ISO 639-1 code: en, de, es, fr, it, nl, pt, ru or uk.
+// Words are lowercase with diacritics, so they don't match texts tokenized with FoldDiacritics.
+// It returns nil for other languages.
+func StopWords(language string) map[string]bool {
+	var words string
+
+	switch language {
+	case "en":
+		words = "a an and are as at be but by for from has have if in into is it its no not of on or " +
+			"such that the their then there these they this to was were will with"
+	case "de":
+		words = "aber als am an auch auf aus bei bis das dass dem den der des die du ein eine einem einen " +
+			"einer eines er es für im in ist mit nach nicht noch oder sich sie sind so über um und von vor " +
+			"wie wird zu zum zur"
+	case "es":
+		words = "a al como con de del el en es esta este la las lo los no o para pero por que se sin " +
+			"su sus un una y"
+	case "fr":
+		words = "à au aux avec ce ces dans de des du elle en est et il ils la le les leur ne ou par pas " +
+			"pour qu que qui sa se ses son sont sur un une"
+	case "it":
+		words = "a al alla che con da dal del della di e gli i il in la le lo non o per si su un una uno"
+	case "nl":
+		words = "aan als bij dat de der die dit een en het in is met niet of om op te tot van voor zijn"
+	case "pt":
+		words = "a ao aos as com da das de do dos e é em na nas no nos o os ou para pela pelo por que se " +
+			"sem seu sua um uma"
+	case "ru":
+		words = "а без в во да для до же за и из или к как ли на не но о об от по при с со то у что это"
+	case "uk":
+		words = "а або без в від до же з за зі і із й на не ні по при та те то у чи що це як"
+	default:
+		return nil
+	}
+
+	set := map[string]bool{}
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+
+	return set
+}
@@ -7,7 +7,7 @@ import (
 )
 
 const (
-	spacesRegexp = `\s+`
+	spacesRegexp = `[\s\p{Z}\x{85}]+`
 	space        = " "
 
 	copyrightSign        = "©"
@@ -19,31 +19,174 @@ const (
 	httpPattern     = `https://`
 	httpReplacement = `http://`
 
-	enDash = "\u2013"
-	emDash = "\u2014"
 	hyphen = "-"
 )
 
+// Tokenizer splits texts in tokens. Texts are normalized by the SPDX matching guidelines
+// and by Unicode NFKC normalization, invisible characters are removed and all kinds
+// of dashes become a hyphen, so texts copied from PDFs and word processors
+// tokenize like plain ones. The zero value is the tokenizer used by Tokenize.
+type Tokenizer struct {
+	// FoldDiacritics removes accents and other combining marks, so "naïve" equals "naive".
+	FoldDiacritics bool
+	// FoldConfusables replaces letters of other scripts that look like Latin ones,
+	// for example Cyrillic "а" and Greek "Ο", with the Latin letters in words
+	// mixing them with Latin letters and in words of Latin texts.
+	FoldConfusables bool
+	// Segmentation defines how the cleaned up text is split in tokens.
+	Segmentation Segmentation
+	// Punctuation defines what happens to punctuation at the edges of words,
+	// SegmentWords drops it regardless of the mode.
+	Punctuation Punctuation
+	// StopWords are dropped from tokens, see the StopWords function for built-in lists.
+	StopWords map[string]bool
+	// Stem replaces each token with its stem when it's set, it runs after dropping stop words.
+	Stem func(token string) string
+}
+
 // Tokenize cleans up the text making a set of substitutions by this guide:
 // https://spdx.dev/license-list/matching-guidelines/ and slit it in tokens by spaces.
+// It uses the default Tokenizer.
 func Tokenize(text string) []string {
-	cleaned := cleanupText([]byte(text))
-	if len(cleaned) == 0 {
+	return Tokenizer{}.Tokenize(text)
+}
+
+// LicenseTokenizer returns the tokenizer for license matching.
+// It drops punctuation, as the SPDX matching guidelines consider most of it
+// not significant, so a misplaced comma doesn't break pairs of words.
+func LicenseTokenizer() Tokenizer {
+	return Tokenizer{Punctuation: PunctuationDrop}
+}
+
+// Tokenize cleans up the text and splits it in tokens.
+func (t Tokenizer) Tokenize(text string) []string {
+	cleaned := string(t.cleanup([]byte(text)))
+	tokens := []string{}
+
+	for _, word := range t.split(cleaned) {
+		for _, part := range t.Punctuation.apply(word) {
+			if token, ok := t.refine(part); ok {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// split splits the cleaned up text by the segmentation.
+func (t Tokenizer) split(cleaned string) []string {
+	if t.Segmentation == SegmentWords {
+		words := []string{}
+		for _, s := range segmentWords(cleaned) {
+			word := cleaned[s.start:s.end]
+			if word == "https" {
+				// The scheme is a separate word here, so https:// replacement of the cleanup
+				// is not seen when words are cleaned up one by one.
+				word = "http"
+			}
+
+			words = append(words, word)
+		}
+
+		return words
+	}
+
+	if cleaned == "" {
 		return []string{}
 	}
 
-	return strings.Split(string(cleaned), space)
+	return strings.Split(cleaned, space)
+}
+
+// refine drops stop words and stems the rest, ok is false for a dropped word.
+func (t Tokenizer) refine(word string) (string, bool) {
+	if t.StopWords[word] {
+		return "", false
+	}
+
+	if t.Stem != nil {
+		word = t.Stem(word)
+	}
+
+	return word, word != ""
+}
+
+// Token is a token of a text with its position in the text.
+type Token struct {
+	Text string
+	// Start and End are the byte offsets of the source of the token in the text, End is exclusive.
+	Start, End int
+}
+
+// TokenizeWithOffsets splits the text in tokens like Tokenize does and keeps
+// the position of each token in the text. The text is split by spaces first
+// and each word is cleaned up separately, so replacements of several words,
+// like "copyright holder", are not applied, and a word replaced with several
+// ones, like "percent", gives tokens with the same position.
+func TokenizeWithOffsets(text string) []Token {
+	return Tokenizer{}.TokenizeWithOffsets(text)
+}
+
+// TokenizeWithOffsets splits the text in tokens like Tokenize does and keeps
+// the position of each token in the text.
+func (t Tokenizer) TokenizeWithOffsets(text string) []Token {
+	var words []span
+
+	if t.Segmentation == SegmentWords {
+		// Invisible characters are removed by the cleanup, so they must not break words here.
+		visible, offsets := removeInvisible(text)
+		for _, word := range segmentWords(visible) {
+			words = append(words, span{start: offsets[word.start], end: offsets[word.end-1] + 1})
+		}
+	} else {
+		for _, word := range regexp.MustCompile(`[^\s\p{Z}\x{85}]+`).FindAllStringIndex(text, -1) {
+			words = append(words, span{start: word[0], end: word[1]})
+		}
+	}
+
+	tokens := []Token{}
+	latin := t.FoldConfusables && isLatinText([]byte(text))
+
+	for _, word := range words {
+		for _, cleaned := range t.split(string(t.cleanupIn([]byte(text[word.start:word.end]), latin))) {
+			for _, part := range t.Punctuation.apply(cleaned) {
+				if token, ok := t.refine(part); ok {
+					tokens = append(tokens, Token{Text: token, Start: word.start, End: word.end})
+				}
+			}
+		}
+	}
+
+	return tokens
 }
 
 func cleanupText(text []byte) []byte {
+	return Tokenizer{}.cleanup(text)
+}
+
+func (t Tokenizer) cleanup(text []byte) []byte {
+	return t.cleanupIn(text, t.FoldConfusables && isLatinText(text))
+}
+
+// cleanupIn cleans up a part of a text, latin reports whether the whole text is written in Latin letters.
+func (t Tokenizer) cleanupIn(text []byte, latin bool) []byte {
+	text = normalizeUnicode(text)
+
+	if t.FoldDiacritics {
+		text = foldDiacritics(text)
+	}
+
+	if t.FoldConfusables {
+		text = foldConfusables(text, latin)
+	}
+
 	text = bytes.ToLower(text)
 	text = regexp.MustCompile(spacesRegexp).ReplaceAll(text, []byte(space))
 	text = regexp.MustCompile(quotesRegexp).ReplaceAll(text, []byte(quotesReplacement))
 
 	text = bytes.ReplaceAll(text, []byte(httpPattern), []byte(httpReplacement))
 	text = bytes.ReplaceAll(text, []byte(copyrightSign), []byte(copyrightReplacement))
-	text = bytes.ReplaceAll(text, []byte(emDash), []byte(hyphen))
-	text = bytes.ReplaceAll(text, []byte(enDash), []byte(hyphen))
 	text = bytes.TrimSpace(text)
 	text = replaceEqualWords(text)
 
@@ -224,3 +224,54 @@ func TestTokenize(t *testing.T) {
 		assert.Equal(t, []string{}, result)
 	})
 }
+
+func TestTokenizeWithOffsets(t *testing.T) {
+	t.Run("common_text", func(t *testing.T) {
+		text := "  Lorem “ipsum” dolor —\n© 2001\tpercent "
+
+		assert.Equal(t, []Token{
+			{Text: "lorem", Start: 2, End: 7},
+			{Text: "'ipsum'", Start: 8, End: 19},
+			{Text: "dolor", Start: 20, End: 25},
+			{Text: "-", Start: 26, End: 29},
+			{Text: "(c)", Start: 30, End: 32},
+			{Text: "2001", Start: 33, End: 37},
+			{Text: "per", Start: 38, End: 45},
+			{Text: "cent", Start: 38, End: 45},
+		}, TokenizeWithOffsets(text))
+	})
+
+	t.Run("same_tokens_as_tokenize", func(t *testing.T) {
+		texts := []string{
+			`Lorem ipsum dolor sit amet, "consectetur" adipiscing elit, https://sed.do/eiusmod`,
+			"zero\u200bwidth soft\u00adhyphen \ufeffstart end\u200d",
+			"本软\u200b件按原样提供 mit\u2060license",
+			"Licensed under the ΜΙΤ license, сор",
+		}
+
+		tokenizers := map[string]Tokenizer{
+			"spaces": {},
+			"words":  {Segmentation: SegmentWords},
+			"folded": {FoldConfusables: true},
+		}
+
+		for name, tokenizer := range tokenizers {
+			for _, text := range texts {
+				tokens := tokenizer.TokenizeWithOffsets(text)
+				words := make([]string, len(tokens))
+				for i, token := range tokens {
+					words[i] = token.Text
+				}
+
+				assert.Equal(t, tokenizer.Tokenize(text), words, name)
+			}
+		}
+
+		tokens := Tokenizer{Segmentation: SegmentWords}.TokenizeWithOffsets("a zero\u200bwidth b")
+		assert.Equal(t, Token{Text: "zerowidth", Start: 2, End: 14}, tokens[1])
+	})
+
+	t.Run("only_spaces", func(t *testing.T) {
+		assert.Equal(t, []Token{}, TokenizeWithOffsets(" \n\t "))
+	})
+}
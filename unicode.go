@@ -0,0 +1,233 @@
+package compare
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeUnicode applies NFKC normalization, which unifies full-width letters,
+// ligatures and no-break spaces with their plain forms, then removes invisible
+// characters and replaces all dashes and minus signs with a hyphen.
+func normalizeUnicode(text []byte) []byte {
+	text = norm.NFKC.Bytes(text)
+
+	return bytes.Map(func(r rune) rune {
+		switch {
+		case isInvisible(r):
+			return -1
+		case isDash(r):
+			return '-'
+		default:
+			return r
+		}
+	}, text)
+}
+
+// isInvisible reports whether the character has no glyph and doesn't separate words,
+// like zero-width spaces, joiners, the soft hyphen and the byte order mark.
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u00ad', '\u034f', '\u061c', '\u115f', '\u1160', '\u17b4', '\u17b5', '\u180e',
+		'\u200b', '\u200c', '\u200d', '\u200e', '\u200f', '\u2060', '\u2061', '\u2062',
+		'\u2063', '\u2064', '\ufeff':
+		return true
+	default:
+		return unicode.In(r, unicode.Variation_Selector)
+	}
+}
+
+// removeInvisible removes invisible characters from the text, offsets map each byte
+// of the result to the byte offset in the text.
+func removeInvisible(text string) (string, []int) {
+	var b strings.Builder
+
+	offsets := make([]int, 0, len(text))
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		if !isInvisible(r) {
+			b.WriteString(text[i : i+size])
+
+			for j := i; j < i+size; j++ {
+				offsets = append(offsets, j)
+			}
+		}
+
+		i += size
+	}
+
+	return b.String(), offsets
+}
+
+// isDash reports whether the character is a dash, a hyphen or a minus sign.
+// Wave dashes are not, as they are used as tildes.
+func isDash(r rune) bool {
+	switch r {
+	case '\u2212', '\u2796':
+		return true
+	case '\u301c', '\u3030':
+		return false
+	default:
+		return unicode.In(r, unicode.Dash)
+	}
+}
+
+// foldDiacritics removes combining marks from decomposed characters.
+func foldDiacritics(text []byte) []byte {
+	folded, _, err := transform.Bytes(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		return text
+	}
+
+	return folded
+}
+
+// foldConfusables replaces Cyrillic and Greek letters which look like Latin ones
+// in words that mix them with Latin letters. Words of lookalike letters only, like "ΜΙΤ",
+// are replaced when latin is set, that is the text around them is Latin,
+// so words of texts in those languages, like Russian "сор", stay intact.
+// Only letters that are indistinguishable in common fonts are replaced.
+func foldConfusables(text []byte, latin bool) []byte {
+	result := make([]byte, 0, len(text))
+
+	for len(text) > 0 {
+		end := bytes.IndexFunc(text, unicode.IsSpace)
+		if end < 0 {
+			end = len(text)
+		}
+
+		if word := text[:end]; isSpoofed(word, latin) {
+			result = append(result, bytes.Map(confusable, word)...)
+		} else {
+			result = append(result, word...)
+		}
+
+		spaces := bytes.IndexFunc(text[end:], func(r rune) bool { return !unicode.IsSpace(r) })
+		if spaces < 0 {
+			spaces = len(text) - end
+		}
+
+		result = append(result, text[end:end+spaces]...)
+		text = text[end+spaces:]
+	}
+
+	return result
+}
+
+// isSpoofed reports whether the word has lookalike letters mixed with Latin ones,
+// or consists of lookalike letters only in a Latin text.
+func isSpoofed(word []byte, latin bool) bool {
+	hasLatin, lookalike, other := false, false, false
+
+	for _, r := range string(word) {
+		switch {
+		case confusable(r) != r:
+			lookalike = true
+		case unicode.Is(unicode.Latin, r):
+			hasLatin = true
+		case unicode.IsLetter(r):
+			other = true
+		}
+	}
+
+	return lookalike && (hasLatin || latin && !other)
+}
+
+// isLatinText reports whether the text has more Latin letters than letters of other scripts,
+// lookalike letters are not counted as they may belong to either.
+func isLatinText(text []byte) bool {
+	latin, other := 0, 0
+
+	for _, r := range string(text) {
+		switch {
+		case confusable(r) != r:
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.IsLetter(r):
+			other++
+		}
+	}
+
+	return latin > other
+}
+
+//nolint:funlen,gocyclo // The function is a table of lookalike letters
+func confusable(r rune) rune {
+	switch r {
+	case 'А', 'Α':
+		return 'A'
+	case 'В', 'Β':
+		return 'B'
+	case 'С', 'Ϲ':
+		return 'C'
+	case 'Е', 'Ε':
+		return 'E'
+	case 'Н', 'Η':
+		return 'H'
+	case 'І', 'Ι', 'Ӏ':
+		return 'I'
+	case 'Ј':
+		return 'J'
+	case 'К', 'Κ':
+		return 'K'
+	case 'М', 'Μ':
+		return 'M'
+	case 'Ν':
+		return 'N'
+	case 'О', 'Ο':
+		return 'O'
+	case 'Р', 'Ρ':
+		return 'P'
+	case 'Ѕ':
+		return 'S'
+	case 'Т', 'Τ':
+		return 'T'
+	case 'Х', 'Χ':
+		return 'X'
+	case 'У', 'Υ', 'Ү':
+		return 'Y'
+	case 'Ζ':
+		return 'Z'
+	case 'а':
+		return 'a'
+	case 'с', 'ϲ':
+		return 'c'
+	case 'ԁ':
+		return 'd'
+	case 'е':
+		return 'e'
+	case 'һ':
+		return 'h'
+	case 'і':
+		return 'i'
+	case 'ј':
+		return 'j'
+	case 'ӏ':
+		return 'l'
+	case 'о', 'ο':
+		return 'o'
+	case 'р', 'ρ':
+		return 'p'
+	case 'ԛ':
+		return 'q'
+	case 'ѕ':
+		return 's'
+	case 'ν':
+		return 'v'
+	case 'ԝ':
+		return 'w'
+	case 'х':
+		return 'x'
+	case 'у':
+		return 'y'
+	default:
+		return r
+	}
+}
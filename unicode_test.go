@@ -0,0 +1,92 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenizer_Tokenize(t *testing.T) {
+	type testcase struct {
+		tokenizer Tokenizer
+		input     string
+		expected  []string
+	}
+
+	testcases := map[string]testcase{
+		"no_break_spaces_separate_words": {
+			input:    "lorem\u00a0ipsum\u202fdolor\u3000sit amet",
+			expected: []string{"lorem", "ipsum", "dolor", "sit", "amet"},
+		},
+		"invisible_characters_removed": {
+			input:    "lo\u200brem ip\u00adsum \ufeffdolor si\u200dt a\u2060met",
+			expected: []string{"lorem", "ipsum", "dolor", "sit", "amet"},
+		},
+		"full_width_letters_and_ligatures": {
+			input:    "Ｌｏｒｅｍ ipsum ﬁnal ﬂow",
+			expected: []string{"lorem", "ipsum", "final", "flow"},
+		},
+		"all_dashes_are_hyphens": {
+			input:    "a\u2010b\u2011c\u2012d\u2015e\u2212f\ufe63g\uff0dh",
+			expected: []string{"a-b-c-d-e-f-g-h"},
+		},
+		"combining_accents_composed": {
+			input:    "cafe\u0301 café",
+			expected: []string{"café", "café"},
+		},
+		"diacritics_kept_by_default": {
+			input:    "naïve résumé",
+			expected: []string{"naïve", "résumé"},
+		},
+		"diacritics_folded": {
+			tokenizer: Tokenizer{FoldDiacritics: true},
+			input:     "Naïve RÉSUMÉ cafe\u0301",
+			expected:  []string{"naive", "resume", "cafe"},
+		},
+		"confusables_kept_by_default": {
+			input:    "Соpyright",
+			expected: []string{"соpyright"},
+		},
+		"confusables_folded": {
+			tokenizer: Tokenizer{FoldConfusables: true},
+			input:     "Соpyright ΜΙΤ ѕуѕtеm",
+			expected:  []string{"copyright", "mit", "system"},
+		},
+		"other_scripts_kept": {
+			tokenizer: Tokenizer{FoldConfusables: true},
+			input:     "ліцензія λόγος ΚΑΙ",
+			expected:  []string{"ліцензія", "λόγος", "και"},
+		},
+		"turkish_dotless_i_kept": {
+			tokenizer: Tokenizer{FoldConfusables: true},
+			input:     "kılınç lisansı",
+			expected:  []string{"kılınç", "lisansı"},
+		},
+		"lookalike_words_of_other_scripts_kept": {
+			tokenizer: Tokenizer{FoldConfusables: true},
+			input:     "сор и мусор, о чём",
+			expected:  []string{"сор", "и", "мусор,", "о", "чём"},
+		},
+		"lookalike_words_in_latin_text_folded": {
+			tokenizer: Tokenizer{FoldConfusables: true},
+			input:     "Licensed under the ΜΙΤ license",
+			expected:  []string{"licensed", "under", "the", "mit", "license"},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.tokenizer.Tokenize(tc.input))
+		})
+	}
+}
+
+func TestTokenizer_TokenizeWithOffsets(t *testing.T) {
+	text := "Lorem\u00a0“ipsum”\u200b dolor"
+
+	assert.Equal(t, []Token{
+		{Text: "lorem", Start: 0, End: 5},
+		{Text: "'ipsum'", Start: 7, End: 21},
+		{Text: "dolor", Start: 22, End: 27},
+	}, Tokenizer{}.TokenizeWithOffsets(text))
+}